@@ -0,0 +1,1160 @@
+/******************************************
+* Fake Neo4j Aura API for offline tests
+******************************************/
+package auratest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"terraform-provider-pgrneo4jaura/aura"
+	"time"
+)
+
+const (
+	// TenantID is the tenant every new Server is seeded with.
+	TenantID     = "00000000-0000-0000-0000-000000000000"
+	TenantName   = "auratest"
+	ClientID     = "auratest-client-id"
+	ClientSecret = "auratest-client-secret"
+)
+
+// Server is an in-memory stand-in for the Aura API. Instances, cmks and
+// snapshots move through the same transitional states as the real api
+// (creating, pausing, resuming, updating, destroying, pending, deleting,
+// InProgress) and settle after TransitionPolls status reads.
+type Server struct {
+	*httptest.Server
+
+	// TransitionPolls is the number of GET requests an object answers with
+	// its transitional status before settling. defaults to 1.
+	TransitionPolls int
+
+	// DatabaseStartPolls is the number of requests the database of a new
+	// instance refuses after the instance reports running. defaults to 0.
+	DatabaseStartPolls int
+
+	// TokenLifetime is the expires_in handed out with access tokens.
+	// defaults to an hour, like Aura.
+	TokenLifetime time.Duration
+
+	mu            sync.Mutex
+	nextID        int
+	tokens        map[string]time.Time
+	tokenRequests int
+	failures      []failure
+	lostQueries   int
+	tenants       map[string]*aura.Tenant
+	instances     map[string]*instance
+	cmks          map[string]*cmk
+	snapshots     map[string]*snapshot
+}
+
+type failure struct {
+	statusCode int
+	retryAfter string
+}
+
+type instance struct {
+	aura.Instance
+	version  string
+	created  string
+	password string
+	// origin is where the data of the instance came from, "instance:<id>"
+	// or "snapshot:<id>", empty for an instance created empty.
+	origin string
+	// status to settle in once pending reaches 0. an empty target removes
+	// the instance.
+	target  string
+	pending int
+	// starting is the number of requests the database still refuses.
+	starting int
+}
+
+type cmk struct {
+	aura.CMK
+	target  string
+	pending int
+}
+
+type snapshot struct {
+	aura.Snapshot
+	target  string
+	pending int
+}
+
+// NewServer starts a fake Aura API seeded with a single tenant.
+func NewServer() *Server {
+	s := &Server{
+		TransitionPolls: 1,
+		TokenLifetime:   time.Hour,
+		tokens:          map[string]time.Time{},
+		tenants:         map[string]*aura.Tenant{},
+		instances:       map[string]*instance{},
+		cmks:            map[string]*cmk{},
+		snapshots:       map[string]*snapshot{},
+	}
+	s.AddTenant(aura.Tenant{
+		ID:                     TenantID,
+		Name:                   TenantName,
+		InstanceConfigurations: DefaultInstanceConfigurations(),
+	})
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// DefaultInstanceConfigurations is the catalog new tenants are seeded with.
+func DefaultInstanceConfigurations() []aura.InstanceConfiguration {
+	var configurations []aura.InstanceConfiguration
+	regions := map[string][]string{
+		"aws":   {"us-east-1", "us-west-2", "eu-west-1"},
+		"gcp":   {"us-central1", "europe-west1"},
+		"azure": {"eastus"},
+	}
+	for cloudProvider, names := range regions {
+		for _, region := range names {
+			for _, instanceType := range []string{"enterprise-db", "enterprise-ds", "professional-db", "professional-ds"} {
+				for _, gb := range []int{2, 4, 8, 16, 32} {
+					configurations = append(configurations, aura.InstanceConfiguration{
+						CloudProvider: cloudProvider,
+						Memory:        fmt.Sprintf("%dGB", gb),
+						Region:        region,
+						RegionName:    region,
+						Storage:       fmt.Sprintf("%dGB", gb*2),
+						Type:          instanceType,
+						Version:       "5",
+					})
+				}
+			}
+		}
+	}
+	return configurations
+}
+
+// AddTenant registers a tenant with the server.
+func (s *Server) AddTenant(tenant aura.Tenant) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tenants[tenant.ID] = &tenant
+}
+
+// Instance returns a copy of the stored instance, regardless of its status.
+func (s *Server) Instance(id string) (aura.Instance, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i, ok := s.instances[id]
+	if !ok {
+		return aura.Instance{}, false
+	}
+	return i.Instance, true
+}
+
+// Origin returns where the data of an instance came from: "instance:<id>" for
+// a clone of an instance, "snapshot:<id>" for a clone or restore of a
+// snapshot, and "" for an instance created empty.
+func (s *Server) Origin(id string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if i, ok := s.instances[id]; ok {
+		return i.origin
+	}
+	return ""
+}
+
+// Password returns the current password of the neo4j user of an instance.
+func (s *Server) Password(id string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if i, ok := s.instances[id]; ok {
+		return i.password
+	}
+	return ""
+}
+
+// CMK returns a copy of the stored cmk, regardless of its status.
+func (s *Server) CMK(id string) (aura.CMK, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	k, ok := s.cmks[id]
+	if !ok {
+		return aura.CMK{}, false
+	}
+	return k.CMK, true
+}
+
+// AddInstance stores a running instance without going through the api, as
+// if it was created in the Aura console. unlike the api it does not refuse a
+// name already in use. an empty ID is generated, the id is returned.
+func (s *Server) AddInstance(inst aura.Instance) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if inst.ID == "" {
+		inst.ID = s.newID()
+	}
+	if inst.Status == "" {
+		inst.Status = "running"
+	}
+	if inst.ConnectionURL == "" {
+		inst.ConnectionURL = "neo4j+s://" + inst.ID + databaseDomain
+	}
+	if inst.CDCEnrichmentMode == "" {
+		inst.CDCEnrichmentMode = "OFF"
+	}
+	s.instances[inst.ID] = &instance{
+		Instance: inst,
+		version:  "5",
+		created:  time.Now().UTC().Format(time.RFC3339),
+		password: "pwd-" + inst.ID,
+		target:   inst.Status,
+	}
+	return inst.ID
+}
+
+// RemoveInstances drops every instance with the given name without going
+// through the api, as if it was deleted in the Aura console. it returns the
+// number of instances removed.
+func (s *Server) RemoveInstances(name string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	removed := 0
+	for id, i := range s.instances {
+		if i.Name == name {
+			delete(s.instances, id)
+			removed++
+		}
+	}
+	return removed
+}
+
+// RemoveCMKs drops every cmk with the given name without going through the
+// api. it returns the number of cmks removed.
+func (s *Server) RemoveCMKs(name string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	removed := 0
+	for id, k := range s.cmks {
+		if k.Name == name {
+			delete(s.cmks, id)
+			removed++
+		}
+	}
+	return removed
+}
+
+// SetCMKStatus moves every cmk with the given name to status without going
+// through the api, as if its key grant was revoked in the cloud provider. it
+// returns the number of cmks changed.
+func (s *Server) SetCMKStatus(name string, status string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	changed := 0
+	for _, k := range s.cmks {
+		if k.Name == name {
+			k.Status, k.target, k.pending = status, status, 0
+			changed++
+		}
+	}
+	return changed
+}
+
+// AddSnapshot stores a completed snapshot of an instance, for snapshots taken
+// before the test started. an empty SnapshotID is generated, the id is
+// returned.
+func (s *Server) AddSnapshot(snap aura.Snapshot) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if snap.SnapshotID == "" {
+		snap.SnapshotID = s.newID()
+	}
+	if snap.Status == "" {
+		snap.Status = "Completed"
+	}
+	s.snapshots[snap.SnapshotID] = &snapshot{Snapshot: snap, target: snap.Status}
+	return snap.SnapshotID
+}
+
+// ExpireTokens revokes every access token issued so far.
+func (s *Server) ExpireTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens = map[string]time.Time{}
+}
+
+// FailNextRequests answers the next count api requests with statusCode and,
+// when set, a Retry-After header.
+func (s *Server) FailNextRequests(count int, statusCode int, retryAfter string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for n := 0; n < count; n++ {
+		s.failures = append(s.failures, failure{statusCode: statusCode, retryAfter: retryAfter})
+	}
+}
+
+// LoseNextQueryResponses runs the next count database queries but answers
+// them with a 504 and no body, as a gateway timing out after the database
+// committed the statement.
+func (s *Server) LoseNextQueryResponses(count int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lostQueries = s.lostQueries + count
+}
+
+// HTTPClient returns a client that sends every request to the server,
+// including the discovery requests made to the connection url of an
+// instance, so those resolve without dns or tls.
+func (s *Server) HTTPClient() *http.Client {
+	return &http.Client{
+		Timeout:   30 * time.Second,
+		Transport: redirectTransport{target: s.Listener.Addr().String()},
+	}
+}
+
+type redirectTransport struct {
+	target string
+}
+
+func (t redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Host = req.URL.Host
+	req.URL.Scheme = "http"
+	req.URL.Host = t.target
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// TokenRequests is the number of successful oauth/token requests.
+func (s *Server) TokenRequests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tokenRequests
+}
+
+func (s *Server) newID() string {
+	s.nextID = s.nextID + 1
+	return fmt.Sprintf("%08x", s.nextID)
+}
+
+// newUUID returns the next id in the uuid shape the api uses for cmks.
+func (s *Server) newUUID() string {
+	s.nextID = s.nextID + 1
+	return fmt.Sprintf("%08x-0000-4000-8000-%012x", s.nextID, s.nextID)
+}
+
+/****************************************************
+* ROUTING
+****************************************************/
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.URL.Path == "/oauth/token" {
+		s.token(w, r)
+		return
+	}
+	if strings.HasSuffix(r.Host, databaseDomain) {
+		s.database(w, r, strings.TrimSuffix(r.Host, databaseDomain))
+		return
+	}
+	expiry, ok := s.tokens[strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")]
+	if !ok || time.Now().After(expiry) {
+		writeError(w, http.StatusUnauthorized, "invalid or expired token")
+		return
+	}
+
+	if len(s.failures) > 0 {
+		f := s.failures[0]
+		s.failures = s.failures[1:]
+		if f.retryAfter != "" {
+			w.Header().Set("Retry-After", f.retryAfter)
+		}
+		writeError(w, f.statusCode, "injected failure")
+		return
+	}
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) < 2 || parts[0] != "v1" {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	parts = parts[1:]
+
+	switch {
+	case parts[0] == "tenants" && len(parts) == 1 && r.Method == "GET":
+		s.listTenants(w)
+	case parts[0] == "tenants" && len(parts) == 2 && r.Method == "GET":
+		s.getTenant(w, parts[1])
+	case parts[0] == "instances" && len(parts) == 1 && r.Method == "GET":
+		s.listInstances(w, r.URL.Query().Get("tenantId"))
+	case parts[0] == "instances" && len(parts) == 1 && r.Method == "POST":
+		s.createInstance(w, r)
+	case parts[0] == "instances" && len(parts) == 2 && parts[1] == "sizing" && r.Method == "POST":
+		s.sizing(w, r)
+	case parts[0] == "instances" && len(parts) == 2 && r.Method == "GET":
+		s.getInstance(w, parts[1])
+	case parts[0] == "instances" && len(parts) == 2 && r.Method == "PATCH":
+		s.updateInstance(w, r, parts[1])
+	case parts[0] == "instances" && len(parts) == 2 && r.Method == "DELETE":
+		s.deleteInstance(w, parts[1])
+	case parts[0] == "instances" && len(parts) == 3 && (parts[2] == "pause" || parts[2] == "resume") && r.Method == "POST":
+		s.instanceAction(w, parts[1], parts[2])
+	case parts[0] == "instances" && len(parts) == 3 && parts[2] == "clones" && r.Method == "POST":
+		s.cloneInstance(w, r, parts[1])
+	case parts[0] == "instances" && len(parts) == 3 && parts[2] == "overwrite" && r.Method == "POST":
+		s.overwriteInstance(w, r, parts[1])
+	case parts[0] == "instances" && len(parts) == 3 && parts[2] == "snapshots" && r.Method == "GET":
+		s.listSnapshots(w, parts[1], r.URL.Query().Get("date"))
+	case parts[0] == "instances" && len(parts) == 3 && parts[2] == "snapshots" && r.Method == "POST":
+		s.createSnapshot(w, parts[1])
+	case parts[0] == "instances" && len(parts) == 4 && parts[2] == "snapshots" && r.Method == "GET":
+		s.getSnapshot(w, parts[1], parts[3])
+	case parts[0] == "instances" && len(parts) == 5 && parts[2] == "snapshots" && parts[4] == "restore" && r.Method == "POST":
+		s.restoreSnapshot(w, parts[1], parts[3])
+	case parts[0] == "customer-managed-keys" && len(parts) == 1 && r.Method == "GET":
+		s.listCMKs(w, r.URL.Query().Get("tenantId"))
+	case parts[0] == "customer-managed-keys" && len(parts) == 1 && r.Method == "POST":
+		s.createCMK(w, r)
+	case parts[0] == "customer-managed-keys" && len(parts) == 2 && r.Method == "GET":
+		s.getCMK(w, parts[1])
+	case parts[0] == "customer-managed-keys" && len(parts) == 2 && r.Method == "DELETE":
+		s.deleteCMK(w, parts[1])
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+func (s *Server) token(w http.ResponseWriter, r *http.Request) {
+	client_id, client_secret, ok := r.BasicAuth()
+	if !ok || client_id != ClientID || client_secret != ClientSecret {
+		writeError(w, http.StatusUnauthorized, "invalid client credentials")
+		return
+	}
+	s.tokenRequests = s.tokenRequests + 1
+	token := fmt.Sprintf("auratest-access-token-%d", s.tokenRequests)
+	s.tokens[token] = time.Now().Add(s.TokenLifetime)
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": token,
+		"expires_in":   int64(s.TokenLifetime / time.Second),
+		"token_type":   "bearer",
+	})
+}
+
+/****************************************************
+* TENANTS
+****************************************************/
+func (s *Server) listTenants(w http.ResponseWriter) {
+	tenants := []map[string]string{}
+	for _, tenant := range s.tenants {
+		tenants = append(tenants, map[string]string{"id": tenant.ID, "name": tenant.Name})
+	}
+	writeData(w, http.StatusOK, tenants)
+}
+
+func (s *Server) getTenant(w http.ResponseWriter, id string) {
+	tenant, ok := s.tenants[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "tenant "+id+" not found")
+		return
+	}
+	writeData(w, http.StatusOK, tenant)
+}
+
+func (s *Server) instanceConfiguration(tenantID string, cloudProvider string, region string, instanceType string, version string, memory string) (aura.InstanceConfiguration, bool) {
+	tenant, ok := s.tenants[tenantID]
+	if !ok {
+		return aura.InstanceConfiguration{}, false
+	}
+	for _, config := range tenant.InstanceConfigurations {
+		if config.CloudProvider == cloudProvider && config.Region == region && config.Type == instanceType &&
+			(version == "" || config.Version == version) && config.Memory == memory {
+			return config, true
+		}
+	}
+	return aura.InstanceConfiguration{}, false
+}
+
+/****************************************************
+* INSTANCES
+****************************************************/
+// databaseDomain hosts the databases, <instance id>.databases.neo4j.io
+const databaseDomain = ".databases.neo4j.io"
+
+// database serves the endpoints of a running database itself rather than of
+// the Aura API, the discovery document and the query api.
+func (s *Server) database(w http.ResponseWriter, r *http.Request, id string) {
+	switch {
+	case r.URL.Path == "/" && r.Method == "GET":
+		s.discovery(w, id)
+	case r.URL.Path == "/db/system/query/v2" && r.Method == "POST":
+		s.query(w, r, id)
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+// discovery serves the http discovery document of a running database.
+func (s *Server) discovery(w http.ResponseWriter, id string) {
+	i, ok := s.instances[id]
+	if !ok || !i.available() {
+		writeError(w, http.StatusServiceUnavailable, "database "+id+" is not available")
+		return
+	}
+	neo4jVersion := "5.26.0"
+	if i.version == "4" {
+		neo4jVersion = "4.4.0"
+	}
+	writeJSON(w, http.StatusOK, map[string]string{
+		"bolt_routing":  "neo4j+s://" + id + databaseDomain,
+		"neo4j_version": neo4jVersion,
+		"neo4j_edition": "enterprise",
+	})
+}
+
+// query runs a statement against the system database. only changing the
+// password of the neo4j user and showing it, to check credentials, are
+// understood.
+func (s *Server) query(w http.ResponseWriter, r *http.Request, id string) {
+	i, ok := s.instances[id]
+	if !ok || !i.available() {
+		writeError(w, http.StatusServiceUnavailable, "database "+id+" is not available")
+		return
+	}
+	username, password, ok := r.BasicAuth()
+	if !ok || username != "neo4j" || password != i.password {
+		writeQueryError(w, http.StatusUnauthorized, "Neo.ClientError.Security.Unauthorized", "The client is unauthorized due to authentication failure.")
+		return
+	}
+	var payload struct {
+		Statement  string            `json:"statement"`
+		Parameters map[string]string `json:"parameters"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		writeQueryError(w, http.StatusBadRequest, "Neo.ClientError.Request.Invalid", "invalid request body: "+err.Error())
+		return
+	}
+	if payload.Statement == "SHOW CURRENT USER" {
+		writeJSON(w, http.StatusAccepted, map[string]any{
+			"data": map[string]any{"fields": []string{"user"}, "values": []any{[]string{username}}},
+		})
+		return
+	}
+	if !strings.HasPrefix(payload.Statement, "ALTER CURRENT USER SET PASSWORD FROM $") {
+		writeQueryError(w, http.StatusBadRequest, "Neo.ClientError.Statement.SyntaxError", "unsupported statement: "+payload.Statement)
+		return
+	}
+	current, next := payload.Parameters["current"], payload.Parameters["password"]
+	if current != i.password {
+		writeQueryError(w, http.StatusBadRequest, "Neo.ClientError.General.InvalidArguments", "User 'neo4j' failed to alter their own password: Invalid principal or credentials.")
+		return
+	}
+	if len(next) < 8 || next == current {
+		writeQueryError(w, http.StatusBadRequest, "Neo.ClientError.General.InvalidArguments", "A password must be at least 8 characters and differ from the old one.")
+		return
+	}
+	i.password = next
+	if s.lostQueries > 0 {
+		s.lostQueries = s.lostQueries - 1
+		w.WriteHeader(http.StatusGatewayTimeout)
+		return
+	}
+	writeJSON(w, http.StatusAccepted, map[string]any{
+		"data":      map[string]any{"fields": []string{}, "values": []any{}},
+		"bookmarks": []string{"FB:" + s.newID()},
+	})
+}
+
+// available reports whether the database of a running instance accepts
+// connections, a new one refuses the first DatabaseStartPolls requests.
+func (i *instance) available() bool {
+	if i.Status != "running" {
+		return false
+	}
+	if i.starting > 0 {
+		i.starting = i.starting - 1
+		return false
+	}
+	return true
+}
+
+// advance settles an object after enough reads. it reports false once the
+// object should no longer be found.
+func advance(status *string, target *string, pending *int) bool {
+	if *pending > 0 {
+		*pending = *pending - 1
+		return true
+	}
+	if *target == "" {
+		return false
+	}
+	*status = *target
+	return true
+}
+
+func (s *Server) transition(i *instance, status string, target string) {
+	i.Status = status
+	i.target = target
+	i.pending = s.TransitionPolls
+}
+
+func (s *Server) listInstances(w http.ResponseWriter, tenantID string) {
+	instances := []aura.InstanceSummary{}
+	for _, i := range s.instances {
+		if tenantID != "" && i.TenantID != tenantID {
+			continue
+		}
+		instances = append(instances, aura.InstanceSummary{
+			ID:            i.ID,
+			Name:          i.Name,
+			Created:       i.created,
+			TenantID:      i.TenantID,
+			CloudProvider: i.CloudProvider,
+		})
+	}
+	writeData(w, http.StatusOK, instances)
+}
+
+func (s *Server) getInstance(w http.ResponseWriter, id string) {
+	i, ok := s.instances[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "instance "+id+" not found")
+		return
+	}
+	if !advance(&i.Status, &i.target, &i.pending) {
+		delete(s.instances, id)
+		writeError(w, http.StatusNotFound, "instance "+id+" not found")
+		return
+	}
+	writeData(w, http.StatusOK, instanceView(i))
+}
+
+// instanceView renders the instance the way the api does, paused instances
+// have no connection_url or memory but keep their storage.
+func instanceView(i *instance) map[string]any {
+	view := map[string]any{
+		"id":                      i.ID,
+		"name":                    i.Name,
+		"status":                  i.Status,
+		"tenant_id":               i.TenantID,
+		"cloud_provider":          i.CloudProvider,
+		"region":                  i.Region,
+		"type":                    i.Type,
+		"metrics_integration_url": i.MetricsIntegrationURL,
+		"secondaries_count":       i.SecondariesCount,
+		"cdc_enrichment_mode":     i.CDCEnrichmentMode,
+		"vector_optimized":        i.VectorOptimized,
+		"graph_analytics_plugin":  i.GraphAnalyticsPlugin,
+		"connection_url":          nil,
+		"storage":                 i.Storage,
+	}
+	if i.CustomerManagedKeyID != "" {
+		view["customer_managed_key_id"] = i.CustomerManagedKeyID
+	}
+	if i.Status != "paused" && i.Status != "pausing" {
+		view["connection_url"] = i.ConnectionURL
+		view["memory"] = i.Memory
+	}
+	return view
+}
+
+func (s *Server) createInstance(w http.ResponseWriter, r *http.Request) {
+	var payload aura.CreateInstanceRequest
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	s.addInstance(w, payload, "")
+}
+
+func (s *Server) cloneInstance(w http.ResponseWriter, r *http.Request, sourceID string) {
+	var payload aura.CloneInstanceRequest
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	origin, ok := s.sourceOrigin(w, sourceID, payload.SourceSnapshotID, "cloned")
+	if !ok {
+		return
+	}
+	s.addInstance(w, payload.CreateInstanceRequest, origin)
+}
+
+// sourceOrigin checks the source of a clone or overwrite, a completed snapshot
+// of the source instance or the running source instance itself, and returns
+// the origin the data comes from. it writes the error when the source can not
+// be used.
+func (s *Server) sourceOrigin(w http.ResponseWriter, sourceID string, snapshotID string, action string) (string, bool) {
+	source, ok := s.instances[sourceID]
+	if !ok || source.Status == "destroying" {
+		writeError(w, http.StatusNotFound, "instance "+sourceID+" not found")
+		return "", false
+	}
+	if snapshotID != "" {
+		snap, ok := s.snapshots[snapshotID]
+		if !ok || snap.InstanceID != sourceID {
+			writeError(w, http.StatusNotFound, "snapshot "+snapshotID+" of instance "+sourceID+" not found")
+			return "", false
+		}
+		if snap.Status != "Completed" {
+			writeError(w, http.StatusConflict, "snapshot "+snapshotID+" is "+snap.Status)
+			return "", false
+		}
+		return "snapshot:" + snapshotID, true
+	}
+	if source.Status != "running" {
+		writeError(w, http.StatusConflict, "instance "+sourceID+" cannot be "+action+" while "+source.Status)
+		return "", false
+	}
+	return "instance:" + sourceID, true
+}
+
+// addInstance validates and stores a new instance, empty or cloned from origin.
+func (s *Server) addInstance(w http.ResponseWriter, payload aura.CreateInstanceRequest, origin string) {
+	if _, ok := s.tenants[payload.TenantID]; !ok {
+		writeError(w, http.StatusNotFound, "tenant "+payload.TenantID+" not found")
+		return
+	}
+	config, ok := s.instanceConfiguration(payload.TenantID, payload.CloudProvider, payload.Region, payload.Type, payload.Version, payload.Memory)
+	if !ok {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("instance configuration %s/%s/%s/%s/%s is not available", payload.CloudProvider, payload.Region, payload.Type, payload.Version, payload.Memory))
+		return
+	}
+	if payload.CustomerManagedKeyID != "" {
+		k, ok := s.cmks[payload.CustomerManagedKeyID]
+		if !ok {
+			writeError(w, http.StatusBadRequest, "customer managed key "+payload.CustomerManagedKeyID+" not found")
+			return
+		}
+		if k.Status != "ready" {
+			writeError(w, http.StatusBadRequest, "customer managed key "+payload.CustomerManagedKeyID+" is "+k.Status)
+			return
+		}
+	}
+
+	id := s.newID()
+	i := &instance{
+		Instance: aura.Instance{
+			ID:                    id,
+			Name:                  payload.Name,
+			TenantID:              payload.TenantID,
+			CloudProvider:         payload.CloudProvider,
+			Region:                payload.Region,
+			Type:                  payload.Type,
+			Memory:                payload.Memory,
+			Storage:               config.Storage,
+			ConnectionURL:         "neo4j+s://" + id + databaseDomain,
+			MetricsIntegrationURL: "https://customer-metrics-api.neo4j.io/api/v1/" + payload.TenantID + "/" + id + "/metrics",
+			CDCEnrichmentMode:     "OFF",
+			CustomerManagedKeyID:  payload.CustomerManagedKeyID,
+			VectorOptimized:       payload.VectorOptimized,
+			GraphAnalyticsPlugin:  payload.GraphAnalyticsPlugin,
+		},
+		version:  payload.Version,
+		created:  time.Now().UTC().Format(time.RFC3339),
+		password: "pwd-" + id,
+		origin:   origin,
+		starting: s.DatabaseStartPolls,
+	}
+	s.transition(i, "creating", "running")
+	s.instances[id] = i
+	// aura takes a first scheduled snapshot of every new instance
+	snapshotID := s.newID()
+	s.snapshots[snapshotID] = &snapshot{
+		Snapshot: aura.Snapshot{
+			SnapshotID: snapshotID,
+			InstanceID: id,
+			Profile:    "Scheduled",
+			Status:     "Completed",
+			Timestamp:  i.created,
+		},
+		target: "Completed",
+	}
+
+	writeData(w, http.StatusAccepted, map[string]any{
+		"id":             id,
+		"name":           i.Name,
+		"tenant_id":      i.TenantID,
+		"cloud_provider": i.CloudProvider,
+		"region":         i.Region,
+		"type":           i.Type,
+		"connection_url": i.ConnectionURL,
+		"username":       "neo4j",
+		"password":       i.password,
+	})
+}
+
+func (s *Server) updateInstance(w http.ResponseWriter, r *http.Request, id string) {
+	i, ok := s.instances[id]
+	if !ok || i.Status == "destroying" {
+		writeError(w, http.StatusNotFound, "instance "+id+" not found")
+		return
+	}
+	var payload map[string]any
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	changed := false
+	for key, value := range payload {
+		switch key {
+		case "name":
+			name, ok := value.(string)
+			if !ok || name == "" {
+				writeError(w, http.StatusBadRequest, "name must be a non empty string")
+				return
+			}
+			i.Name = name
+		case "memory":
+			memory, _ := value.(string)
+			config, ok := s.instanceConfiguration(i.TenantID, i.CloudProvider, i.Region, i.Type, i.version, memory)
+			if !ok {
+				writeError(w, http.StatusBadRequest, "memory "+memory+" is not available for this instance")
+				return
+			}
+			i.Memory = config.Memory
+			i.Storage = config.Storage
+			changed = true
+		case "secondaries_count":
+			count, ok := value.(float64)
+			if !ok || count < 0 {
+				writeError(w, http.StatusBadRequest, "secondaries_count must be a positive number")
+				return
+			}
+			i.SecondariesCount = int64(count)
+			changed = true
+		case "cdc_enrichment_mode":
+			mode, _ := value.(string)
+			if mode != "OFF" && mode != "DIFF" && mode != "FULL" {
+				writeError(w, http.StatusBadRequest, "cdc_enrichment_mode must be one of OFF, DIFF, FULL")
+				return
+			}
+			i.CDCEnrichmentMode = mode
+			changed = true
+		case "vector_optimized", "graph_analytics_plugin":
+			enabled, err := parseBool(value)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, key+" must be a boolean")
+				return
+			}
+			if key == "vector_optimized" {
+				i.VectorOptimized = enabled
+			} else {
+				i.GraphAnalyticsPlugin = enabled
+			}
+			changed = true
+		default:
+			writeError(w, http.StatusBadRequest, "unsupported field "+key)
+			return
+		}
+	}
+	if changed {
+		target := i.Status
+		if i.target != "" && i.pending > 0 {
+			target = i.target
+		}
+		s.transition(i, "updating", target)
+	}
+	writeData(w, http.StatusOK, instanceView(i))
+}
+
+func parseBool(value any) (bool, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		return strconv.ParseBool(v)
+	}
+	return false, fmt.Errorf("not a boolean")
+}
+
+func (s *Server) deleteInstance(w http.ResponseWriter, id string) {
+	i, ok := s.instances[id]
+	if !ok || i.Status == "destroying" {
+		writeError(w, http.StatusNotFound, "instance "+id+" not found")
+		return
+	}
+	s.transition(i, "destroying", "")
+	writeData(w, http.StatusAccepted, instanceView(i))
+}
+
+func (s *Server) instanceAction(w http.ResponseWriter, id string, action string) {
+	i, ok := s.instances[id]
+	if !ok || i.Status == "destroying" {
+		writeError(w, http.StatusNotFound, "instance "+id+" not found")
+		return
+	}
+	if action == "pause" {
+		if i.Status != "running" {
+			writeError(w, http.StatusConflict, "instance "+id+" cannot be paused while "+i.Status)
+			return
+		}
+		s.transition(i, "pausing", "paused")
+	} else {
+		if i.Status != "paused" {
+			writeError(w, http.StatusConflict, "instance "+id+" cannot be resumed while "+i.Status)
+			return
+		}
+		s.transition(i, "resuming", "running")
+	}
+	writeData(w, http.StatusAccepted, instanceView(i))
+}
+
+func (s *Server) sizing(w http.ResponseWriter, r *http.Request) {
+	var payload aura.SizingEstimateRequest
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	if payload.NodeCount <= 0 || payload.RelationshipCount < 0 || payload.InstanceType == "" {
+		writeError(w, http.StatusBadRequest, "node_count, relationship_count and instance_type are required")
+		return
+	}
+	// roughly 1GB per 2.5M graph elements, rounded up to the next size
+	required := (payload.NodeCount+payload.RelationshipCount)/2500000 + 1
+	recommended := int64(2)
+	for recommended < required && recommended < 32 {
+		recommended = recommended * 2
+	}
+	writeData(w, http.StatusOK, aura.SizingEstimate{
+		DidExceedMaximum:  required > 32,
+		MinRequiredMemory: fmt.Sprintf("%dGB", required),
+		RecommendedSize:   fmt.Sprintf("%dGB", recommended),
+	})
+}
+
+/****************************************************
+* CMK
+****************************************************/
+func (s *Server) listCMKs(w http.ResponseWriter, tenantID string) {
+	cmks := []map[string]string{}
+	for _, k := range s.cmks {
+		if tenantID != "" && k.TenantID != tenantID {
+			continue
+		}
+		cmks = append(cmks, map[string]string{"id": k.ID, "name": k.Name, "tenant_id": k.TenantID})
+	}
+	writeData(w, http.StatusOK, cmks)
+}
+
+func (s *Server) getCMK(w http.ResponseWriter, id string) {
+	// the api rejects an id that is not a uuid before looking it up
+	if len(id) != 36 || strings.Count(id, "-") != 4 {
+		writeError(w, http.StatusBadRequest, "invalid customer managed key id "+id)
+		return
+	}
+	k, ok := s.cmks[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "customer managed key "+id+" not found")
+		return
+	}
+	if !advance(&k.Status, &k.target, &k.pending) {
+		delete(s.cmks, id)
+		writeError(w, http.StatusNotFound, "customer managed key "+id+" not found")
+		return
+	}
+	writeData(w, http.StatusOK, k.CMK)
+}
+
+func (s *Server) createCMK(w http.ResponseWriter, r *http.Request) {
+	var payload aura.CreateCMKRequest
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	if _, ok := s.tenants[payload.TenantID]; !ok {
+		writeError(w, http.StatusNotFound, "tenant "+payload.TenantID+" not found")
+		return
+	}
+	if payload.Name == "" || payload.KeyID == "" {
+		writeError(w, http.StatusBadRequest, "name and key_id are required")
+		return
+	}
+	for _, k := range s.cmks {
+		if k.Name == payload.Name && k.TenantID == payload.TenantID {
+			writeError(w, http.StatusConflict, "customer managed key "+payload.Name+" already exists")
+			return
+		}
+	}
+
+	k := &cmk{
+		CMK: aura.CMK{
+			ID:            s.newUUID(),
+			Name:          payload.Name,
+			TenantID:      payload.TenantID,
+			CloudProvider: payload.CloudProvider,
+			Region:        payload.Region,
+			InstanceType:  payload.InstanceType,
+			KeyID:         payload.KeyID,
+			Status:        "pending",
+			Created:       time.Now().UTC().Format(time.RFC3339),
+		},
+		target:  "ready",
+		pending: s.TransitionPolls,
+	}
+	s.cmks[k.ID] = k
+	writeData(w, http.StatusAccepted, k.CMK)
+}
+
+func (s *Server) deleteCMK(w http.ResponseWriter, id string) {
+	k, ok := s.cmks[id]
+	if !ok || k.Status == "deleting" {
+		writeError(w, http.StatusNotFound, "customer managed key "+id+" not found")
+		return
+	}
+	for _, i := range s.instances {
+		if i.CustomerManagedKeyID == id {
+			writeError(w, http.StatusConflict, "customer managed key "+id+" is in use by instance "+i.ID)
+			return
+		}
+	}
+	k.Status, k.target, k.pending = "deleting", "", s.TransitionPolls
+	writeData(w, http.StatusAccepted, k.CMK)
+}
+
+/****************************************************
+* SNAPSHOTS
+****************************************************/
+func (s *Server) listSnapshots(w http.ResponseWriter, instanceID string, date string) {
+	if _, ok := s.instances[instanceID]; !ok {
+		writeError(w, http.StatusNotFound, "instance "+instanceID+" not found")
+		return
+	}
+	if date == "" {
+		date = time.Now().UTC().Format(time.DateOnly)
+	} else if _, err := time.Parse(time.DateOnly, date); err != nil {
+		writeError(w, http.StatusBadRequest, "date must be formatted YYYY-MM-DD")
+		return
+	}
+	snapshots := []aura.Snapshot{}
+	for _, snap := range s.snapshots {
+		if snap.InstanceID == instanceID && strings.HasPrefix(snap.Timestamp, date) {
+			snapshots = append(snapshots, snap.Snapshot)
+		}
+	}
+	writeData(w, http.StatusOK, snapshots)
+}
+
+func (s *Server) getSnapshot(w http.ResponseWriter, instanceID string, id string) {
+	snap, ok := s.snapshots[id]
+	if !ok || snap.InstanceID != instanceID {
+		writeError(w, http.StatusNotFound, "snapshot "+id+" not found")
+		return
+	}
+	advance(&snap.Status, &snap.target, &snap.pending)
+	writeData(w, http.StatusOK, snap.Snapshot)
+}
+
+func (s *Server) createSnapshot(w http.ResponseWriter, instanceID string) {
+	i, ok := s.instances[instanceID]
+	if !ok {
+		writeError(w, http.StatusNotFound, "instance "+instanceID+" not found")
+		return
+	}
+	if i.Status != "running" {
+		writeError(w, http.StatusConflict, "instance "+instanceID+" cannot be snapshotted while "+i.Status)
+		return
+	}
+	snap := &snapshot{
+		Snapshot: aura.Snapshot{
+			SnapshotID: s.newID(),
+			InstanceID: instanceID,
+			Profile:    "AdHoc",
+			Status:     "InProgress",
+			Timestamp:  time.Now().UTC().Format(time.RFC3339),
+		},
+		target:  "Completed",
+		pending: s.TransitionPolls,
+	}
+	s.snapshots[snap.SnapshotID] = snap
+	writeData(w, http.StatusAccepted, map[string]string{"snapshot_id": snap.SnapshotID})
+}
+
+func (s *Server) overwriteInstance(w http.ResponseWriter, r *http.Request, id string) {
+	var payload aura.OverwriteInstanceRequest
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	i, ok := s.instances[id]
+	if !ok || i.Status == "destroying" {
+		writeError(w, http.StatusNotFound, "instance "+id+" not found")
+		return
+	}
+	if payload.SourceInstanceID == "" && payload.SourceSnapshotID == "" {
+		writeError(w, http.StatusBadRequest, "source_instance_id or source_snapshot_id is required")
+		return
+	}
+	if payload.SourceInstanceID == id && payload.SourceSnapshotID == "" {
+		writeError(w, http.StatusBadRequest, "instance "+id+" cannot be overwritten from itself")
+		return
+	}
+	// a snapshot on its own is one of the overwritten instance
+	if payload.SourceInstanceID == "" {
+		payload.SourceInstanceID = id
+	}
+	if i.Status != "running" {
+		writeError(w, http.StatusConflict, "instance "+id+" cannot be overwritten while "+i.Status)
+		return
+	}
+	origin, ok := s.sourceOrigin(w, payload.SourceInstanceID, payload.SourceSnapshotID, "copied")
+	if !ok {
+		return
+	}
+	i.origin = origin
+	s.transition(i, "overwriting", "running")
+	writeData(w, http.StatusAccepted, instanceView(i))
+}
+
+func (s *Server) restoreSnapshot(w http.ResponseWriter, instanceID string, id string) {
+	i, ok := s.instances[instanceID]
+	if !ok || i.Status == "destroying" {
+		writeError(w, http.StatusNotFound, "instance "+instanceID+" not found")
+		return
+	}
+	snap, ok := s.snapshots[id]
+	if !ok || snap.InstanceID != instanceID {
+		writeError(w, http.StatusNotFound, "snapshot "+id+" not found")
+		return
+	}
+	if snap.Status != "Completed" {
+		writeError(w, http.StatusConflict, "snapshot "+id+" cannot be restored while "+snap.Status)
+		return
+	}
+	if i.Status != "running" {
+		writeError(w, http.StatusConflict, "instance "+instanceID+" cannot be restored while "+i.Status)
+		return
+	}
+	i.origin = "snapshot:" + id
+	s.transition(i, "restoring", "running")
+	writeData(w, http.StatusAccepted, instanceView(i))
+}
+
+/****************************************************
+* HELPER METHODS
+****************************************************/
+func writeData(w http.ResponseWriter, statusCode int, data any) {
+	writeJSON(w, statusCode, map[string]any{"data": data})
+}
+
+func writeQueryError(w http.ResponseWriter, statusCode int, code string, message string) {
+	writeJSON(w, statusCode, map[string]any{
+		"errors": []map[string]string{{"code": code, "message": message}},
+	})
+}
+
+func writeError(w http.ResponseWriter, statusCode int, message string) {
+	writeJSON(w, statusCode, map[string]any{
+		"errors": []map[string]string{{
+			"message": message,
+			"reason":  strings.ReplaceAll(strings.ToLower(http.StatusText(statusCode)), " ", "-"),
+		}},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,750 @@
+package auratest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"terraform-provider-pgrneo4jaura/aura"
+	"testing"
+	"time"
+)
+
+func newTestClient(t *testing.T) (*Server, *aura.Client) {
+	t.Helper()
+	server := NewServer()
+	t.Cleanup(server.Close)
+	client, err := aura.NewClient(context.Background(), aura.Config{
+		APIURL:       server.URL,
+		ClientID:     ClientID,
+		ClientSecret: ClientSecret,
+		PollInterval: time.Millisecond,
+		RetryDelay:   time.Millisecond,
+		HTTPClient:   server.HTTPClient(),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	return server, client
+}
+
+func TestInstanceLifecycle(t *testing.T) {
+	server, client := newTestClient(t)
+	ctx := context.Background()
+
+	instance, credentials, err := client.CreateInstance(ctx, aura.CreateInstanceRequest{
+		Version:       "5",
+		Region:        "us-east-1",
+		Memory:        "4GB",
+		Name:          "lifecycle",
+		Type:          "enterprise-db",
+		TenantID:      TenantID,
+		CloudProvider: "aws",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error creating instance: %v", err)
+	}
+	if instance.Status != "running" || instance.Storage != "8GB" || credentials.Password == "" {
+		t.Fatalf("unexpected instance after create: %+v, %+v", instance, credentials)
+	}
+
+	if _, _, err := client.CreateInstance(ctx, aura.CreateInstanceRequest{Name: "lifecycle", TenantID: TenantID}); err == nil {
+		t.Fatalf("expected an error creating a duplicate instance")
+	}
+
+	instance, err = client.UpdateMemory(ctx, instance.ID, "8GB")
+	if err != nil {
+		t.Fatalf("unexpected error updating memory: %v", err)
+	}
+	if instance.Status != "running" || instance.Memory != "8GB" || instance.Storage != "16GB" {
+		t.Fatalf("unexpected instance after update: %+v", instance)
+	}
+
+	if _, err := client.UpdateMemory(ctx, instance.ID, "3GB"); err == nil {
+		t.Fatalf("expected an error for an unavailable memory size")
+	}
+
+	instance, err = client.UpdateCDCEnrichmentMode(ctx, instance.ID, "DIFF")
+	if err != nil {
+		t.Fatalf("unexpected error updating cdc_enrichment_mode: %v", err)
+	}
+	if instance.Status != "running" || instance.CDCEnrichmentMode != "DIFF" {
+		t.Fatalf("unexpected instance after cdc update: %+v", instance)
+	}
+
+	instance, err = client.PauseInstance(ctx, instance.ID, true)
+	if err != nil {
+		t.Fatalf("unexpected error pausing instance: %v", err)
+	}
+	if instance.Status != "paused" || instance.ConnectionURL != "" || instance.Memory != "" || instance.Storage != "16GB" {
+		t.Fatalf("unexpected instance after pause: %+v", instance)
+	}
+
+	if _, err := client.PauseInstance(ctx, instance.ID, true); err == nil {
+		t.Fatalf("expected an error pausing a paused instance")
+	}
+
+	instance, err = client.ResumeInstance(ctx, instance.ID, true)
+	if err != nil {
+		t.Fatalf("unexpected error resuming instance: %v", err)
+	}
+	if instance.Status != "running" || instance.ConnectionURL == "" {
+		t.Fatalf("unexpected instance after resume: %+v", instance)
+	}
+
+	if err := client.DeleteInstance(ctx, instance.ID); err != nil {
+		t.Fatalf("unexpected error deleting instance: %v", err)
+	}
+	if _, ok := server.Instance(instance.ID); ok {
+		t.Fatalf("expected instance %s to be removed", instance.ID)
+	}
+	if _, err := client.GetInstance(ctx, instance.ID); !aura.IsNotFound(err) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+}
+
+func TestCMKLifecycle(t *testing.T) {
+	server, client := newTestClient(t)
+	ctx := context.Background()
+
+	cmk, err := client.CreateCMK(ctx, aura.CreateCMKRequest{
+		Name:          "lifecycle",
+		TenantID:      TenantID,
+		CloudProvider: "aws",
+		Region:        "us-east-1",
+		InstanceType:  "enterprise-db",
+		KeyID:         "arn:aws:kms:us-east-1:123456789012:key/lifecycle",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error creating cmk: %v", err)
+	}
+	if cmk.Status != "ready" || cmk.Created == "" {
+		t.Fatalf("unexpected cmk after create: %+v", cmk)
+	}
+
+	// instances can not be created with a revoked key
+	server.SetCMKStatus("lifecycle", "revoked")
+	if cmk, err = client.GetCMK(ctx, cmk.ID); err != nil || cmk.Status != "revoked" {
+		t.Fatalf("expected a revoked cmk, got %+v, %v", cmk, err)
+	}
+	_, _, err = client.CreateInstance(ctx, aura.CreateInstanceRequest{
+		Version:              "5",
+		Region:               "us-east-1",
+		Memory:               "2GB",
+		Name:                 "revoked",
+		Type:                 "enterprise-db",
+		TenantID:             TenantID,
+		CloudProvider:        "aws",
+		CustomerManagedKeyID: cmk.ID,
+	})
+	if err == nil {
+		t.Fatalf("expected an error creating an instance with a revoked cmk")
+	}
+
+	if err := client.DeleteCMK(ctx, cmk.ID); err != nil {
+		t.Fatalf("unexpected error deleting cmk: %v", err)
+	}
+	if _, err := client.GetCMK(ctx, cmk.ID); !aura.IsNotFound(err) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+	if _, err := client.GetCMK(ctx, "lifecycle"); !aura.IsBadRequest(err) {
+		t.Fatalf("expected a bad request error for a name, got %v", err)
+	}
+}
+
+func TestTenantAndSizing(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx := context.Background()
+
+	tenant, err := client.GetTenant(ctx, TenantID)
+	if err != nil {
+		t.Fatalf("unexpected error reading tenant: %v", err)
+	}
+	if tenant.Name != TenantName || len(tenant.InstanceConfigurations) == 0 {
+		t.Fatalf("unexpected tenant: %+v", tenant)
+	}
+
+	tenants, err := client.GetTenants(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error listing tenants: %v", err)
+	}
+	if len(tenants) != 1 || tenants[0].ID != TenantID || tenants[0].Name != TenantName {
+		t.Fatalf("unexpected tenants: %+v", tenants)
+	}
+
+	estimate, err := client.SizingEstimate(ctx, aura.SizingEstimateRequest{
+		NodeCount:           1000000,
+		RelationshipCount:   5000000,
+		InstanceType:        "enterprise-ds",
+		AlgorithmCategories: []string{"path-finding"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error estimating size: %v", err)
+	}
+	if estimate.RecommendedSize == "" || estimate.MinRequiredMemory == "" {
+		t.Fatalf("unexpected estimate: %+v", estimate)
+	}
+}
+
+func TestTokenRefresh(t *testing.T) {
+	server, client := newTestClient(t)
+	ctx := context.Background()
+
+	// a revoked token is replaced and the request retried
+	server.ExpireTokens()
+	if _, err := client.GetTenant(ctx, TenantID); err != nil {
+		t.Fatalf("unexpected error after tokens expired: %v", err)
+	}
+	if requests := server.TokenRequests(); requests != 2 {
+		t.Fatalf("expected 2 token requests, got %d", requests)
+	}
+
+	// a token inside the refresh window is replaced before it is used
+	server.TokenLifetime = time.Minute
+	server.ExpireTokens()
+	if _, err := client.GetTenant(ctx, TenantID); err != nil {
+		t.Fatalf("unexpected error after tokens expired: %v", err)
+	}
+	if _, err := client.GetTenant(ctx, TenantID); err != nil {
+		t.Fatalf("unexpected error refreshing token: %v", err)
+	}
+	if requests := server.TokenRequests(); requests != 4 {
+		t.Fatalf("expected 4 token requests, got %d", requests)
+	}
+}
+
+func TestRetries(t *testing.T) {
+	server, client := newTestClient(t)
+	ctx := context.Background()
+
+	server.FailNextRequests(2, http.StatusServiceUnavailable, "0")
+	if _, err := client.GetTenant(ctx, TenantID); err != nil {
+		t.Fatalf("unexpected error after transient failures: %v", err)
+	}
+
+	// POST is not retried on a plain server error
+	server.FailNextRequests(1, http.StatusInternalServerError, "")
+	_, err := client.SizingEstimate(ctx, aura.SizingEstimateRequest{NodeCount: 1, InstanceType: "enterprise-db"})
+	if err == nil {
+		t.Fatalf("expected a POST to fail on an internal server error")
+	}
+
+	// POST is retried when rate limited
+	server.FailNextRequests(1, http.StatusTooManyRequests, "0")
+	if _, err := client.SizingEstimate(ctx, aura.SizingEstimateRequest{NodeCount: 1, InstanceType: "enterprise-db"}); err != nil {
+		t.Fatalf("unexpected error after rate limiting: %v", err)
+	}
+
+	server.FailNextRequests(aura.DefaultMaxAttempts, http.StatusTooManyRequests, "0")
+	if _, err := client.GetTenant(ctx, TenantID); err == nil {
+		t.Fatalf("expected an error once attempts are exhausted")
+	}
+}
+
+func TestCancelledContext(t *testing.T) {
+	server, client := newTestClient(t)
+
+	// a long Retry-After must not outlive the context
+	server.FailNextRequests(1, http.StatusServiceUnavailable, "120")
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := client.GetTenant(ctx, TenantID)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected a deadline exceeded error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("request was not cancelled, took %s", elapsed)
+	}
+}
+
+func TestWaitHonorsDeadline(t *testing.T) {
+	server, client := newTestClient(t)
+	ctx := context.Background()
+
+	instance, _, err := client.CreateInstance(ctx, aura.CreateInstanceRequest{
+		Version:       "5",
+		Region:        "us-east-1",
+		Memory:        "2GB",
+		Name:          "deadline",
+		Type:          "enterprise-db",
+		TenantID:      TenantID,
+		CloudProvider: "aws",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error creating instance: %v", err)
+	}
+
+	// an update that never settles must stop at the operation deadline
+	server.TransitionPolls = 1 << 30
+	updateCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	_, err = client.UpdateMemory(updateCtx, instance.ID, "4GB")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected a deadline exceeded error, got %v", err)
+	}
+	if got, _ := server.Instance(instance.ID); got.Status != "updating" {
+		t.Fatalf("expected instance to still be updating, got %s", got.Status)
+	}
+}
+
+func TestWaitSettlesUnchangedStatus(t *testing.T) {
+	server := NewServer()
+	t.Cleanup(server.Close)
+	ctx := context.Background()
+	settleDelay := 200 * time.Millisecond
+	client, err := aura.NewClient(ctx, aura.Config{
+		APIURL:       server.URL,
+		ClientID:     ClientID,
+		ClientSecret: ClientSecret,
+		PollInterval: time.Millisecond,
+		RetryDelay:   time.Millisecond,
+		SettleDelay:  settleDelay,
+		HTTPClient:   server.HTTPClient(),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	instance, _, err := client.CreateInstance(ctx, aura.CreateInstanceRequest{
+		Version:       "5",
+		Region:        "us-east-1",
+		Memory:        "2GB",
+		Name:          "settle",
+		Type:          "enterprise-db",
+		TenantID:      TenantID,
+		CloudProvider: "aws",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error creating instance: %v", err)
+	}
+
+	// a status seen to change needs no settle delay
+	start := time.Now()
+	if _, err := client.UpdateMemory(ctx, instance.ID, "4GB"); err != nil {
+		t.Fatalf("unexpected error updating instance: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed >= settleDelay {
+		t.Fatalf("expected no settle delay after an observed status change, took %s", elapsed)
+	}
+
+	// an update that settles before the first read is only trusted after it
+	server.TransitionPolls = 0
+	start = time.Now()
+	if _, err := client.UpdateMemory(ctx, instance.ID, "8GB"); err != nil {
+		t.Fatalf("unexpected error updating instance: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < settleDelay {
+		t.Fatalf("expected the settle delay when the status never changed, took %s", elapsed)
+	}
+}
+
+func TestVersionDiscovery(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx := context.Background()
+
+	instance, _, err := client.CreateInstance(ctx, aura.CreateInstanceRequest{
+		Version:       "5",
+		Region:        "us-east-1",
+		Memory:        "2GB",
+		Name:          "discovery",
+		Type:          "enterprise-db",
+		TenantID:      TenantID,
+		CloudProvider: "aws",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error creating instance: %v", err)
+	}
+	version, err := client.DiscoverVersion(ctx, instance.ConnectionURL)
+	if err != nil {
+		t.Fatalf("unexpected error discovering version: %v", err)
+	}
+	if version != "5" {
+		t.Fatalf("expected version 5, got %s", version)
+	}
+
+	// a paused database can not be asked
+	if _, err := client.PauseInstance(ctx, instance.ID, true); err != nil {
+		t.Fatalf("unexpected error pausing instance: %v", err)
+	}
+	if _, err := client.DiscoverVersion(ctx, instance.ConnectionURL); err == nil {
+		t.Fatalf("expected an error discovering the version of a paused instance")
+	}
+}
+
+func TestWaitForDatabase(t *testing.T) {
+	server, client := newTestClient(t)
+	server.DatabaseStartPolls = 2
+	ctx := context.Background()
+
+	instance, credentials, err := client.CreateInstance(ctx, aura.CreateInstanceRequest{
+		Version:       "5",
+		Region:        "us-east-1",
+		Memory:        "2GB",
+		Name:          "starting",
+		Type:          "enterprise-db",
+		TenantID:      TenantID,
+		CloudProvider: "aws",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error creating instance: %v", err)
+	}
+	// running in the api, but the database does not accept connections yet
+	if _, err := client.DiscoverVersion(ctx, instance.ConnectionURL); err == nil {
+		t.Fatalf("expected an error discovering the version of a starting database")
+	}
+	version, err := client.WaitForDatabase(ctx, instance.ConnectionURL)
+	if err != nil {
+		t.Fatalf("unexpected error waiting for the database: %v", err)
+	}
+	if version != "5" {
+		t.Fatalf("expected version 5, got %s", version)
+	}
+	if err := client.ChangePassword(ctx, instance.ConnectionURL, "neo4j", credentials.Password, "started-password"); err != nil {
+		t.Fatalf("unexpected error changing password: %v", err)
+	}
+
+	// a paused database never accepts connections, the deadline ends the wait
+	if _, err := client.PauseInstance(ctx, instance.ID, true); err != nil {
+		t.Fatalf("unexpected error pausing instance: %v", err)
+	}
+	deadline, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.WaitForDatabase(deadline, instance.ConnectionURL); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected a deadline exceeded error waiting for a paused database, got %v", err)
+	}
+}
+
+func TestSnapshots(t *testing.T) {
+	server, client := newTestClient(t)
+	ctx := context.Background()
+
+	instance, _, err := client.CreateInstance(ctx, aura.CreateInstanceRequest{
+		Version:       "5",
+		Region:        "us-east-1",
+		Memory:        "2GB",
+		Name:          "snapshots",
+		Type:          "enterprise-db",
+		TenantID:      TenantID,
+		CloudProvider: "aws",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error creating instance: %v", err)
+	}
+
+	snapshot, err := client.CreateSnapshot(ctx, instance.ID)
+	if err != nil {
+		t.Fatalf("unexpected error taking snapshot: %v", err)
+	}
+	if snapshot.Status != "Completed" || snapshot.Profile != "AdHoc" || snapshot.InstanceID != instance.ID {
+		t.Fatalf("unexpected snapshot: %+v", snapshot)
+	}
+
+	// today holds the scheduled snapshot of the new instance and the adhoc one
+	snapshots, err := client.GetSnapshots(ctx, instance.ID, "")
+	if err != nil {
+		t.Fatalf("unexpected error listing snapshots: %v", err)
+	}
+	if len(snapshots) != 2 {
+		t.Fatalf("expected 2 snapshots today, got %+v", snapshots)
+	}
+
+	id := server.AddSnapshot(aura.Snapshot{InstanceID: instance.ID, Profile: "Scheduled", Timestamp: "2024-03-15T10:32:01Z"})
+	snapshots, err = client.GetSnapshots(ctx, instance.ID, "2024-03-15")
+	if err != nil {
+		t.Fatalf("unexpected error listing snapshots: %v", err)
+	}
+	if len(snapshots) != 1 || snapshots[0].SnapshotID != id {
+		t.Fatalf("expected the seeded snapshot, got %+v", snapshots)
+	}
+
+	if _, err := client.PauseInstance(ctx, instance.ID, true); err != nil {
+		t.Fatalf("unexpected error pausing instance: %v", err)
+	}
+	if _, err := client.CreateSnapshot(ctx, instance.ID); err == nil {
+		t.Fatalf("expected an error taking a snapshot of a paused instance")
+	}
+	if _, err := client.GetSnapshot(ctx, instance.ID, "missing"); !aura.IsNotFound(err) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+}
+
+func TestCloneInstance(t *testing.T) {
+	server, client := newTestClient(t)
+	ctx := context.Background()
+
+	request := aura.CreateInstanceRequest{
+		Version:       "5",
+		Region:        "us-east-1",
+		Memory:        "2GB",
+		Name:          "source",
+		Type:          "enterprise-db",
+		TenantID:      TenantID,
+		CloudProvider: "aws",
+	}
+	source, _, err := client.CreateInstance(ctx, request)
+	if err != nil {
+		t.Fatalf("unexpected error creating instance: %v", err)
+	}
+	other, _, err := client.CreateInstance(ctx, aura.CreateInstanceRequest{
+		Version:       "5",
+		Region:        "us-east-1",
+		Memory:        "2GB",
+		Name:          "other",
+		Type:          "enterprise-db",
+		TenantID:      TenantID,
+		CloudProvider: "aws",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error creating instance: %v", err)
+	}
+	if origin := server.Origin(source.ID); origin != "" {
+		t.Fatalf("expected no origin for a new instance, got %q", origin)
+	}
+
+	request.Name = "clone"
+	clone, credentials, err := client.CloneInstance(ctx, source.ID, aura.CloneInstanceRequest{CreateInstanceRequest: request})
+	if err != nil {
+		t.Fatalf("unexpected error cloning instance: %v", err)
+	}
+	if clone.Status != "running" || clone.ID == source.ID || credentials.Password == "" {
+		t.Fatalf("unexpected clone: %+v", clone)
+	}
+	if origin := server.Origin(clone.ID); origin != "instance:"+source.ID {
+		t.Fatalf("expected the clone to come from %s, got %q", source.ID, origin)
+	}
+
+	snapshot, err := client.CreateSnapshot(ctx, source.ID)
+	if err != nil {
+		t.Fatalf("unexpected error taking snapshot: %v", err)
+	}
+	request.Name = "snapshotclone"
+	clone, _, err = client.CloneInstance(ctx, source.ID, aura.CloneInstanceRequest{CreateInstanceRequest: request, SourceSnapshotID: snapshot.SnapshotID})
+	if err != nil {
+		t.Fatalf("unexpected error cloning snapshot: %v", err)
+	}
+	if origin := server.Origin(clone.ID); origin != "snapshot:"+snapshot.SnapshotID {
+		t.Fatalf("expected the clone to come from snapshot %s, got %q", snapshot.SnapshotID, origin)
+	}
+
+	// a snapshot only clones through the instance it was taken of
+	request.Name = "mismatch"
+	if _, _, err := client.CloneInstance(ctx, other.ID, aura.CloneInstanceRequest{CreateInstanceRequest: request, SourceSnapshotID: snapshot.SnapshotID}); err == nil {
+		t.Fatalf("expected an error cloning a snapshot of another instance")
+	}
+	if _, _, err := client.CloneInstance(ctx, "missing", aura.CloneInstanceRequest{CreateInstanceRequest: request}); err == nil {
+		t.Fatalf("expected an error cloning a missing instance")
+	}
+}
+
+func TestRestoreSnapshot(t *testing.T) {
+	server, client := newTestClient(t)
+	ctx := context.Background()
+
+	request := aura.CreateInstanceRequest{
+		Version:       "5",
+		Region:        "us-east-1",
+		Memory:        "2GB",
+		Name:          "restore",
+		Type:          "enterprise-db",
+		TenantID:      TenantID,
+		CloudProvider: "aws",
+	}
+	instance, _, err := client.CreateInstance(ctx, request)
+	if err != nil {
+		t.Fatalf("unexpected error creating instance: %v", err)
+	}
+	request.Name = "other"
+	other, _, err := client.CreateInstance(ctx, request)
+	if err != nil {
+		t.Fatalf("unexpected error creating instance: %v", err)
+	}
+	snapshot, err := client.CreateSnapshot(ctx, instance.ID)
+	if err != nil {
+		t.Fatalf("unexpected error taking snapshot: %v", err)
+	}
+
+	restored, err := client.RestoreSnapshot(ctx, instance.ID, snapshot.SnapshotID)
+	if err != nil {
+		t.Fatalf("unexpected error restoring snapshot: %v", err)
+	}
+	if restored.Status != "running" {
+		t.Fatalf("expected the instance to be running after the restore, got %q", restored.Status)
+	}
+	if origin := server.Origin(instance.ID); origin != "snapshot:"+snapshot.SnapshotID {
+		t.Fatalf("expected the instance to hold snapshot %s, got %q", snapshot.SnapshotID, origin)
+	}
+
+	if _, err := client.RestoreSnapshot(ctx, other.ID, snapshot.SnapshotID); !aura.IsNotFound(err) {
+		t.Fatalf("expected a not found error restoring a snapshot of another instance, got %v", err)
+	}
+	if _, err := client.PauseInstance(ctx, instance.ID, true); err != nil {
+		t.Fatalf("unexpected error pausing instance: %v", err)
+	}
+	if _, err := client.RestoreSnapshot(ctx, instance.ID, snapshot.SnapshotID); err == nil {
+		t.Fatalf("expected an error restoring to a paused instance")
+	}
+}
+
+func TestOverwriteInstance(t *testing.T) {
+	server, client := newTestClient(t)
+	ctx := context.Background()
+
+	request := aura.CreateInstanceRequest{
+		Version:       "5",
+		Region:        "us-east-1",
+		Memory:        "2GB",
+		Name:          "prod",
+		Type:          "enterprise-db",
+		TenantID:      TenantID,
+		CloudProvider: "aws",
+	}
+	prod, _, err := client.CreateInstance(ctx, request)
+	if err != nil {
+		t.Fatalf("unexpected error creating instance: %v", err)
+	}
+	request.Name = "dev"
+	dev, _, err := client.CreateInstance(ctx, request)
+	if err != nil {
+		t.Fatalf("unexpected error creating instance: %v", err)
+	}
+
+	overwritten, err := client.OverwriteInstance(ctx, dev.ID, aura.OverwriteInstanceRequest{SourceInstanceID: prod.ID})
+	if err != nil {
+		t.Fatalf("unexpected error overwriting instance: %v", err)
+	}
+	if overwritten.Status != "running" {
+		t.Fatalf("expected the instance to be running after the overwrite, got %q", overwritten.Status)
+	}
+	if origin := server.Origin(dev.ID); origin != "instance:"+prod.ID {
+		t.Fatalf("expected the instance to hold the data of %s, got %q", prod.ID, origin)
+	}
+
+	snapshot, err := client.CreateSnapshot(ctx, prod.ID)
+	if err != nil {
+		t.Fatalf("unexpected error taking snapshot: %v", err)
+	}
+	if _, err := client.OverwriteInstance(ctx, dev.ID, aura.OverwriteInstanceRequest{SourceInstanceID: prod.ID, SourceSnapshotID: snapshot.SnapshotID}); err != nil {
+		t.Fatalf("unexpected error overwriting instance from snapshot: %v", err)
+	}
+	if origin := server.Origin(dev.ID); origin != "snapshot:"+snapshot.SnapshotID {
+		t.Fatalf("expected the instance to hold snapshot %s, got %q", snapshot.SnapshotID, origin)
+	}
+
+	own, err := client.CreateSnapshot(ctx, dev.ID)
+	if err != nil {
+		t.Fatalf("unexpected error taking snapshot: %v", err)
+	}
+	if _, err := client.OverwriteInstance(ctx, dev.ID, aura.OverwriteInstanceRequest{SourceSnapshotID: own.SnapshotID}); err != nil {
+		t.Fatalf("unexpected error overwriting instance from its own snapshot: %v", err)
+	}
+	if origin := server.Origin(dev.ID); origin != "snapshot:"+own.SnapshotID {
+		t.Fatalf("expected the instance to hold snapshot %s, got %q", own.SnapshotID, origin)
+	}
+	if _, err := client.OverwriteInstance(ctx, dev.ID, aura.OverwriteInstanceRequest{SourceSnapshotID: snapshot.SnapshotID}); !aura.IsNotFound(err) {
+		t.Fatalf("expected a not found error for a snapshot of another instance without its source, got %v", err)
+	}
+	if _, err := client.OverwriteInstance(ctx, dev.ID, aura.OverwriteInstanceRequest{}); !aura.IsBadRequest(err) {
+		t.Fatalf("expected a bad request error without a source, got %v", err)
+	}
+
+	if _, err := client.OverwriteInstance(ctx, dev.ID, aura.OverwriteInstanceRequest{SourceInstanceID: dev.ID}); err == nil {
+		t.Fatalf("expected an error overwriting an instance from itself")
+	}
+	if _, err := client.OverwriteInstance(ctx, dev.ID, aura.OverwriteInstanceRequest{SourceInstanceID: dev.ID, SourceSnapshotID: snapshot.SnapshotID}); !aura.IsNotFound(err) {
+		t.Fatalf("expected a not found error for a snapshot of another instance, got %v", err)
+	}
+	if _, err := client.PauseInstance(ctx, prod.ID, true); err != nil {
+		t.Fatalf("unexpected error pausing instance: %v", err)
+	}
+	if _, err := client.OverwriteInstance(ctx, dev.ID, aura.OverwriteInstanceRequest{SourceInstanceID: prod.ID}); err == nil {
+		t.Fatalf("expected an error overwriting from a paused instance")
+	}
+}
+
+func TestChangePassword(t *testing.T) {
+	server, client := newTestClient(t)
+	ctx := context.Background()
+
+	instance, credentials, err := client.CreateInstance(ctx, aura.CreateInstanceRequest{
+		Version:       "5",
+		Region:        "us-east-1",
+		Memory:        "2GB",
+		Name:          "password",
+		Type:          "enterprise-db",
+		TenantID:      TenantID,
+		CloudProvider: "aws",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error creating instance: %v", err)
+	}
+
+	if err := client.ChangePassword(ctx, instance.ConnectionURL, "neo4j", credentials.Password, "rotated-password"); err != nil {
+		t.Fatalf("unexpected error changing password: %v", err)
+	}
+	if password := server.Password(instance.ID); password != "rotated-password" {
+		t.Fatalf("expected the password to be changed, got %q", password)
+	}
+
+	// the old password no longer signs in
+	if err := client.ChangePassword(ctx, instance.ConnectionURL, "neo4j", credentials.Password, "another-password"); err == nil {
+		t.Fatalf("expected an error changing the password with the old one")
+	}
+	if err := client.ChangePassword(ctx, instance.ConnectionURL, "neo4j", "rotated-password", "short"); err == nil {
+		t.Fatalf("expected an error changing to a too short password")
+	}
+
+	// a change applied but answered with a gateway timeout signs in with the new password
+	server.LoseNextQueryResponses(1)
+	if err := client.ChangePassword(ctx, instance.ConnectionURL, "neo4j", "rotated-password", "recovered-password"); err != nil {
+		t.Fatalf("unexpected error changing password with a lost response: %v", err)
+	}
+	if password := server.Password(instance.ID); password != "recovered-password" {
+		t.Fatalf("expected the password to be changed, got %q", password)
+	}
+	if ok, err := client.VerifyPassword(ctx, instance.ConnectionURL, "neo4j", "recovered-password"); err != nil || !ok {
+		t.Fatalf("expected the new password to sign in, got %v, %v", ok, err)
+	}
+	if ok, err := client.VerifyPassword(ctx, instance.ConnectionURL, "neo4j", "rotated-password"); err != nil || ok {
+		t.Fatalf("expected the old password to be refused, got %v, %v", ok, err)
+	}
+
+	if _, err := client.PauseInstance(ctx, instance.ID, true); err != nil {
+		t.Fatalf("unexpected error pausing instance: %v", err)
+	}
+	if err := client.ChangePassword(ctx, instance.ConnectionURL, "neo4j", "recovered-password", "another-password"); err == nil {
+		t.Fatalf("expected an error changing the password of a paused instance")
+	}
+
+	// neo4j 4 has no query api
+	server.AddTenant(aura.Tenant{
+		ID:   "11111111-1111-1111-1111-111111111111",
+		Name: "legacy",
+		InstanceConfigurations: []aura.InstanceConfiguration{{
+			CloudProvider: "aws",
+			Memory:        "2GB",
+			Region:        "us-east-1",
+			RegionName:    "us-east-1",
+			Storage:       "4GB",
+			Type:          "enterprise-db",
+			Version:       "4",
+		}},
+	})
+	legacy, legacyCredentials, err := client.CreateInstance(ctx, aura.CreateInstanceRequest{
+		Version:       "4",
+		Region:        "us-east-1",
+		Memory:        "2GB",
+		Name:          "legacy",
+		Type:          "enterprise-db",
+		TenantID:      "11111111-1111-1111-1111-111111111111",
+		CloudProvider: "aws",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error creating instance: %v", err)
+	}
+	if err := client.ChangePassword(ctx, legacy.ConnectionURL, "neo4j", legacyCredentials.Password, "another-password"); err == nil || !strings.Contains(err.Error(), "no query api") {
+		t.Fatalf("expected an error changing the password of a neo4j 4 instance, got %v", err)
+	}
+	if password := server.Password(legacy.ID); password != legacyCredentials.Password {
+		t.Fatalf("expected the password of a neo4j 4 instance to be unchanged, got %q", password)
+	}
+}
@@ -0,0 +1,301 @@
+/******************************************
+* Neo4j Aura Administrative API client
+* https://neo4j.com/docs/aura/platform/api/specification/
+******************************************/
+package aura
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// DefaultAPIURL is the public Neo4j Aura API.
+const DefaultAPIURL = "https://api.neo4j.io"
+
+const (
+	DefaultPollInterval = 15 * time.Second
+	DefaultSettleDelay  = 60 * time.Second
+	DefaultMaxAttempts  = 5
+	DefaultRetryDelay   = 1 * time.Second
+
+	// tokens are refreshed this long before they expire so a request never
+	// goes out with a token that expires in flight.
+	tokenRefreshWindow = 5 * time.Minute
+)
+
+// Config holds everything needed to construct a Client.
+type Config struct {
+	// APIURL is the base url every request, including oauth/token, is sent
+	// to. defaults to DefaultAPIURL.
+	APIURL       string
+	ClientID     string
+	ClientSecret string
+	// PollInterval is the time between status checks while waiting for an
+	// action to complete. defaults to DefaultPollInterval.
+	PollInterval time.Duration
+	// SettleDelay is an extra wait after an action is taken as complete
+	// without its status having been seen to change, in case the api had not
+	// picked the action up yet. zero disables it.
+	SettleDelay time.Duration
+	// MaxAttempts is the number of times a request is sent before giving up
+	// on retryable failures. defaults to DefaultMaxAttempts.
+	MaxAttempts int
+	// RetryDelay is the base of the exponential backoff between attempts.
+	// defaults to DefaultRetryDelay.
+	RetryDelay time.Duration
+	// HTTPClient is shared by every request. defaults to a client with a 30
+	// second timeout.
+	HTTPClient *http.Client
+}
+
+// Client talks to the Neo4j Aura API on behalf of the provider. All responses
+// are decoded into the typed structs of this package so callers never have to
+// assert on raw json maps.
+type Client struct {
+	baseURL      string
+	httpClient   *http.Client
+	tokens       *tokenSource
+	pollInterval time.Duration
+	settleDelay  time.Duration
+	maxAttempts  int
+	retryDelay   time.Duration
+}
+
+// NewClient authenticates with the client credentials and returns a client
+// that keeps its access token fresh for as long as it is used.
+func NewClient(ctx context.Context, config Config) (*Client, error) {
+	baseURL, err := parseAPIURL(config.APIURL)
+	if err != nil {
+		return nil, err
+	}
+	c := &Client{
+		baseURL:      baseURL,
+		httpClient:   config.HTTPClient,
+		pollInterval: config.PollInterval,
+		settleDelay:  config.SettleDelay,
+		maxAttempts:  config.MaxAttempts,
+		retryDelay:   config.RetryDelay,
+	}
+	if c.httpClient == nil {
+		c.httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	if c.pollInterval <= 0 {
+		c.pollInterval = DefaultPollInterval
+	}
+	if c.maxAttempts <= 0 {
+		c.maxAttempts = DefaultMaxAttempts
+	}
+	if c.retryDelay <= 0 {
+		c.retryDelay = DefaultRetryDelay
+	}
+	c.tokens = &tokenSource{
+		client:        c,
+		client_id:     config.ClientID,
+		client_secret: config.ClientSecret,
+	}
+	// authenticate up front so bad credentials fail provider configuration
+	if _, err := c.tokens.Token(ctx); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func parseAPIURL(api_url string) (string, error) {
+	if api_url == "" {
+		return DefaultAPIURL, nil
+	}
+	u, err := url.Parse(api_url)
+	if err != nil {
+		return "", fmt.Errorf("invalid api url %q: %v", api_url, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("invalid api url %q: must be an absolute http(s) url", api_url)
+	}
+	return strings.TrimRight(api_url, "/"), nil
+}
+
+// APIError is returned for any non 2xx response from the Aura API.
+type APIError struct {
+	StatusCode int
+	Message    string
+	Reason     string
+}
+
+func (e *APIError) Error() string {
+	message := e.Message
+	if message == "" {
+		message = e.Reason
+	}
+	if message == "" {
+		message = http.StatusText(e.StatusCode)
+	}
+	return fmt.Sprintf("%d - %s", e.StatusCode, message)
+}
+
+// IsNotFound reports whether err is an APIError for a 404 response.
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// IsBadRequest reports whether err is an APIError for a 400 response, which
+// the API also returns for an id that is not shaped like one.
+func IsBadRequest(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusBadRequest
+}
+
+type envelope struct {
+	Data json.RawMessage `json:"data"`
+}
+
+type errorEnvelope struct {
+	Errors []struct {
+		Message string `json:"message"`
+		Reason  string `json:"reason"`
+		Field   string `json:"field"`
+	} `json:"errors"`
+}
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int64  `json:"expires_in"`
+	TokenType   string `json:"token_type"`
+}
+
+// tokenSource hands out the current access token and fetches a new one
+// when it is about to expire or has been rejected by the api.
+type tokenSource struct {
+	client        *Client
+	client_id     string
+	client_secret string
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+func (ts *tokenSource) Token(ctx context.Context) (string, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	if ts.token != "" && time.Now().Add(tokenRefreshWindow).Before(ts.expiry) {
+		return ts.token, nil
+	}
+	tflog.Debug(ctx, "requesting new neo4j aura access token")
+	token, err := ts.authenticate(ctx)
+	if err != nil {
+		return "", err
+	}
+	expiresIn := time.Duration(token.ExpiresIn) * time.Second
+	if expiresIn <= 0 {
+		expiresIn = time.Hour // aura tokens are valid for an hour
+	}
+	ts.token = token.AccessToken
+	ts.expiry = time.Now().Add(expiresIn)
+	return ts.token, nil
+}
+
+// Invalidate forces the next Token call to authenticate again, unless the
+// rejected token has already been replaced by another request.
+func (ts *tokenSource) Invalidate(token string) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	if ts.token == token {
+		ts.token = ""
+	}
+}
+
+func (ts *tokenSource) authenticate(ctx context.Context) (*tokenResponse, error) {
+	statusCode, bodyBytes, err := ts.client.send(ctx, "POST", ts.client.baseURL+"/oauth/token", `{"grant_type":"client_credentials"}`, func(req *http.Request) {
+		req.SetBasicAuth(ts.client_id, ts.client_secret)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if statusCode != http.StatusOK {
+		return nil, fmt.Errorf("error during authentication %d : %s - client id: %s", statusCode, string(bodyBytes), ts.client_id)
+	}
+	var token tokenResponse
+	if err := json.Unmarshal(bodyBytes, &token); err != nil {
+		return nil, fmt.Errorf("unable to decode authentication response: %v", err)
+	}
+	if token.AccessToken == "" {
+		return nil, fmt.Errorf("authentication response did not include an access token - client id: %s", ts.client_id)
+	}
+	return &token, nil
+}
+
+/****************************************************
+* HELPER METHODS
+****************************************************/
+// do sends the request and decodes the "data" member of a successful response
+// into out. out may be nil when the caller does not need the response body.
+func (c *Client) do(ctx context.Context, method string, path string, payload any, out any) error {
+	messagebody := ""
+	if payload != nil {
+		payloadBytes, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("failed to marshal payload: %v", err)
+		}
+		messagebody = string(payloadBytes)
+	}
+
+	var bodyBytes []byte
+	var statusCode int
+	for attempt := 1; ; attempt++ {
+		token, err := c.tokens.Token(ctx)
+		if err != nil {
+			return err
+		}
+		statusCode, bodyBytes, err = c.send(ctx, method, c.baseURL+path, messagebody, func(req *http.Request) {
+			req.Header.Set("Authorization", "Bearer "+token)
+		})
+		if err != nil {
+			return err
+		}
+		// the token may have been revoked or expired early, retry once with a new one
+		if statusCode == http.StatusUnauthorized && attempt == 1 {
+			tflog.Info(ctx, fmt.Sprintf("%s %s was unauthorized, refreshing access token", method, path))
+			c.tokens.Invalidate(token)
+			continue
+		}
+		break
+	}
+
+	if statusCode < 200 || statusCode >= 300 {
+		return responseError(statusCode, bodyBytes)
+	}
+	if out == nil || len(strings.TrimSpace(string(bodyBytes))) == 0 {
+		return nil
+	}
+	var resp envelope
+	if err := json.Unmarshal(bodyBytes, &resp); err != nil {
+		return fmt.Errorf("unable to decode %s %s response: %v", method, path, err)
+	}
+	if len(resp.Data) == 0 || string(resp.Data) == "null" {
+		return fmt.Errorf("%s %s response did not include any data", method, path)
+	}
+	if err := json.Unmarshal(resp.Data, out); err != nil {
+		return fmt.Errorf("unable to decode %s %s response: %v", method, path, err)
+	}
+	return nil
+}
+
+func responseError(statusCode int, body []byte) error {
+	apiErr := &APIError{StatusCode: statusCode}
+	var errResp errorEnvelope
+	if json.Unmarshal(body, &errResp) == nil && len(errResp.Errors) > 0 {
+		apiErr.Message = errResp.Errors[0].Message
+		apiErr.Reason = errResp.Errors[0].Reason
+	}
+	return apiErr
+}
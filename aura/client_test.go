@@ -0,0 +1,76 @@
+package aura
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewClientHonorsAPIURL(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/oauth/token":
+			w.Write([]byte(`{"access_token":"token","expires_in":3600,"token_type":"bearer"}`))
+		case "/v1/instances/partial":
+			w.Write([]byte(`{"data":{"id":"partial","status":null,"connection_url":null}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors":[{"message":"instance not found","reason":"not-found"}]}`))
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client, err := NewClient(ctx, Config{APIURL: server.URL + "/", ClientID: "id", ClientSecret: "secret"})
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+
+	if _, err := client.GetInstance(ctx, "partial"); err == nil {
+		t.Fatalf("expected an error for an instance response without a status")
+	}
+
+	_, err = client.GetInstance(ctx, "missing")
+	if !IsNotFound(err) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+
+	expected := []string{"/oauth/token", "/v1/instances/partial", "/v1/instances/missing"}
+	if len(paths) != len(expected) {
+		t.Fatalf("expected requests %v, got %v", expected, paths)
+	}
+	for i := range expected {
+		if paths[i] != expected[i] {
+			t.Fatalf("expected requests %v, got %v", expected, paths)
+		}
+	}
+}
+
+func TestNewClientRejectsInvalidAPIURL(t *testing.T) {
+	if _, err := NewClient(context.Background(), Config{APIURL: "api.neo4j.io"}); err == nil {
+		t.Fatalf("expected an error for a relative api url")
+	}
+}
+
+func TestMajorVersion(t *testing.T) {
+	for neo4j_version, expected := range map[string]string{
+		"5.26.0":    "5",
+		"5.27-aura": "5",
+		"4.4.0":     "4",
+		"2025.01.0": "5",
+	} {
+		version, err := MajorVersion(neo4j_version)
+		if err != nil || version != expected {
+			t.Errorf("MajorVersion(%q) = %q, %v, expected %q", neo4j_version, version, err, expected)
+		}
+	}
+	for _, neo4j_version := range []string{"", "aura", "3.5.0"} {
+		if _, err := MajorVersion(neo4j_version); err == nil {
+			t.Errorf("expected an error for %q", neo4j_version)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package aura
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// CMK is a customer managed key. The /customer-managed-keys listing only
+// populates ID, Name and TenantID.
+type CMK struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	TenantID      string `json:"tenant_id"`
+	CloudProvider string `json:"cloud_provider"`
+	Region        string `json:"region"`
+	InstanceType  string `json:"instance_type"`
+	KeyID         string `json:"key_id"`
+	Status        string `json:"status"`
+	Created       string `json:"created"`
+}
+
+type CreateCMKRequest struct {
+	Name          string `json:"name"`
+	TenantID      string `json:"tenant_id"`
+	CloudProvider string `json:"cloud_provider"`
+	Region        string `json:"region"`
+	InstanceType  string `json:"instance_type"`
+	KeyID         string `json:"key_id"`
+}
+
+func (k *CMK) validate() error {
+	if k.ID == "" {
+		return fmt.Errorf("cmk response did not include an id")
+	}
+	if k.Status == "" {
+		return fmt.Errorf("cmk %s response did not include a status", k.ID)
+	}
+	return nil
+}
+
+func (c *Client) GetCMKs(ctx context.Context) ([]CMK, error) {
+	var cmks []CMK
+	if err := c.do(ctx, "GET", "/v1/customer-managed-keys", nil, &cmks); err != nil {
+		return nil, err
+	}
+	return cmks, nil
+}
+
+func (c *Client) GetCMK(ctx context.Context, cmkid string) (*CMK, error) {
+	var resp CMK
+	if err := c.do(ctx, "GET", "/v1/customer-managed-keys/"+url.PathEscape(cmkid), nil, &resp); err != nil {
+		return nil, err
+	}
+	if err := resp.validate(); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// CMKExists reports whether a cmk with the given name exists in the tenant.
+func (c *Client) CMKExists(ctx context.Context, tenant_id string, name string) (bool, error) {
+	cmks, err := c.GetCMKs(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, cmk := range cmks {
+		if cmk.Name == name && (cmk.TenantID == "" || cmk.TenantID == tenant_id) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// CreateCMK creates the cmk and waits for it to be ready.
+func (c *Client) CreateCMK(ctx context.Context, payload CreateCMKRequest) (*CMK, error) {
+	exists, err := c.CMKExists(ctx, payload.TenantID, payload.Name)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, fmt.Errorf("cmk %s already exists", payload.Name)
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("create cmk payload %+v.", payload))
+	var created CMK
+	if err := c.do(ctx, "POST", "/v1/customer-managed-keys", payload, &created); err != nil {
+		return nil, err
+	}
+	if created.ID == "" {
+		return nil, fmt.Errorf("create cmk response did not include an id")
+	}
+	return c.WaitForCMK(ctx, created.ID, "create")
+}
+
+func (c *Client) DeleteCMK(ctx context.Context, cmkid string) error {
+	if err := c.do(ctx, "DELETE", "/v1/customer-managed-keys/"+url.PathEscape(cmkid), nil, nil); err != nil {
+		return err
+	}
+	_, err := c.WaitForCMK(ctx, cmkid, "delete")
+	return err
+}
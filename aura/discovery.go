@@ -0,0 +1,82 @@
+package aura
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// discoveryResponse is the part of the neo4j http discovery document the
+// provider uses. it is served on the root of every running database.
+type discoveryResponse struct {
+	Neo4jVersion string `json:"neo4j_version"`
+	Neo4jEdition string `json:"neo4j_edition"`
+}
+
+// DiscoverVersion asks a running instance which neo4j version it runs and
+// returns it as the major version the Aura API expects, "4" or "5". the
+// instances endpoint does not report the version, the database does.
+func (c *Client) DiscoverVersion(ctx context.Context, connection_url string) (string, error) {
+	baseURL, err := databaseURL(connection_url)
+	if err != nil {
+		return "", err
+	}
+	discoveryURL := baseURL + "/"
+
+	req, err := http.NewRequestWithContext(ctx, "GET", discoveryURL, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "application/json")
+	r, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("unable to reach %s: %w", discoveryURL, err)
+	}
+	defer r.Body.Close()
+	bodyBytes, err := io.ReadAll(r.Body)
+	if err != nil {
+		return "", err
+	}
+	if r.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%s returned http %d", discoveryURL, r.StatusCode)
+	}
+	var discovery discoveryResponse
+	if err := json.Unmarshal(bodyBytes, &discovery); err != nil {
+		return "", fmt.Errorf("unable to decode discovery response from %s: %v", discoveryURL, err)
+	}
+	return MajorVersion(discovery.Neo4jVersion)
+}
+
+// databaseURL maps the bolt connection url of an instance to the http url the
+// database serves its discovery document and query api on.
+func databaseURL(connection_url string) (string, error) {
+	u, err := url.Parse(connection_url)
+	if err != nil || u.Host == "" {
+		return "", fmt.Errorf("invalid connection url %q", connection_url)
+	}
+	scheme := "http"
+	if strings.HasSuffix(u.Scheme, "+s") || strings.HasSuffix(u.Scheme, "+ssc") {
+		scheme = "https"
+	}
+	return scheme + "://" + u.Hostname(), nil
+}
+
+// MajorVersion maps a neo4j server version such as "5.26.0", "4.4-aura" or
+// the calendar versioned "2025.01.0" to the version the Aura API expects.
+// calendar versions continue the 5 series as far as Aura is concerned.
+func MajorVersion(neo4j_version string) (string, error) {
+	major, _, _ := strings.Cut(neo4j_version, ".")
+	number, err := strconv.Atoi(major)
+	if err != nil || number < 4 {
+		return "", fmt.Errorf("unrecognized neo4j version %q", neo4j_version)
+	}
+	if number == 4 {
+		return "4", nil
+	}
+	return "5", nil
+}
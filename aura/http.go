@@ -0,0 +1,127 @@
+package aura
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// backoff between attempts never exceeds maxRetryDelay, a Retry-After
+	// header from the api is honored up to maxRetryAfter.
+	maxRetryDelay = 30 * time.Second
+	maxRetryAfter = 5 * time.Minute
+)
+
+// send executes a single logical request on the shared http client and
+// returns the status code and the fully read body. transport errors, including
+// timeouts, 429 and 5xx responses are retried with exponential backoff up to
+// maxAttempts. requests that are not idempotent are only retried when the api
+// signals the request was not processed (429 and 503). the request is bound to
+// ctx so cancelling terraform stops it, including any backoff in progress.
+func (c *Client) send(ctx context.Context, method string, url string, messagebody string, authorize func(*http.Request)) (int, []byte, error) {
+	idempotent := method != "POST"
+	for attempt := 1; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, method, url, strings.NewReader(messagebody))
+		if err != nil {
+			return 0, nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		authorize(req)
+
+		var delay time.Duration
+		r, err := c.httpClient.Do(req)
+		if err != nil {
+			if ctx.Err() != nil {
+				return 0, nil, fmt.Errorf("%s %s cancelled: %w", method, url, ctx.Err())
+			}
+			// a POST that failed in flight may still have been processed
+			if !idempotent {
+				if isTimeout(err) {
+					return 0, nil, fmt.Errorf("%s %s timed out and was not retried as it may have been processed: %w", method, url, err)
+				}
+				return 0, nil, err
+			}
+			if attempt >= c.maxAttempts {
+				return 0, nil, fmt.Errorf("unable to execute %s request after %d attempts\n\turl: %s\n\terror: %w", method, attempt, url, err)
+			}
+			delay = c.backoff(attempt)
+			tflog.Warn(ctx, fmt.Sprintf("%s %s failed on attempt %d: %s. retrying in %s", method, url, attempt, err, delay))
+		} else {
+			bodyBytes, err := io.ReadAll(r.Body)
+			r.Body.Close()
+			if err != nil {
+				return 0, nil, err
+			}
+			retryable := r.StatusCode == http.StatusTooManyRequests || r.StatusCode == http.StatusServiceUnavailable ||
+				(idempotent && r.StatusCode >= 500)
+			if !retryable || attempt >= c.maxAttempts {
+				return r.StatusCode, bodyBytes, nil
+			}
+			delay = c.backoff(attempt)
+			if retryAfter, ok := parseRetryAfter(r.Header.Get("Retry-After")); ok {
+				delay = retryAfter
+			}
+			tflog.Warn(ctx, fmt.Sprintf("%s %s returned http %d on attempt %d. retrying in %s", method, url, r.StatusCode, attempt, delay))
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return 0, nil, fmt.Errorf("%s %s cancelled: %w", method, url, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff doubles the retry delay every attempt and picks a random point in
+// the upper half so concurrent applies do not retry in lockstep.
+func (c *Client) backoff(attempt int) time.Duration {
+	delay := c.retryDelay << (attempt - 1)
+	if delay <= 0 || delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// parseRetryAfter accepts both forms of the header, delay seconds and an
+// http date.
+func parseRetryAfter(header string) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+	var delay time.Duration
+	if seconds, err := strconv.Atoi(header); err == nil {
+		delay = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(header); err == nil {
+		delay = time.Until(date)
+	} else {
+		return 0, false
+	}
+	if delay < 0 {
+		delay = 0
+	}
+	if delay > maxRetryAfter {
+		delay = maxRetryAfter
+	}
+	return delay, true
+}
+
+// isTimeout matches client timeouts however deeply they are wrapped.
+func isTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
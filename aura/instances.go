@@ -0,0 +1,253 @@
+package aura
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Instance is the detailed view returned by /instances/{instanceId}. Paused
+// instances omit connection_url, memory and storage.
+type Instance struct {
+	ID                    string `json:"id"`
+	Name                  string `json:"name"`
+	Status                string `json:"status"`
+	TenantID              string `json:"tenant_id"`
+	CloudProvider         string `json:"cloud_provider"`
+	Region                string `json:"region"`
+	Type                  string `json:"type"`
+	Memory                string `json:"memory"`
+	Storage               string `json:"storage"`
+	ConnectionURL         string `json:"connection_url"`
+	MetricsIntegrationURL string `json:"metrics_integration_url"`
+	SecondariesCount      int64  `json:"secondaries_count"`
+	CDCEnrichmentMode     string `json:"cdc_enrichment_mode"`
+	CustomerManagedKeyID  string `json:"customer_managed_key_id"`
+	VectorOptimized       bool   `json:"vector_optimized"`
+	GraphAnalyticsPlugin  bool   `json:"graph_analytics_plugin"`
+}
+
+// InstanceSummary is a single entry of the /instances listing.
+type InstanceSummary struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	Created       string `json:"created_at"`
+	TenantID      string `json:"tenant_id"`
+	CloudProvider string `json:"cloud_provider"`
+}
+
+type CreateInstanceRequest struct {
+	Version              string `json:"version"`
+	Region               string `json:"region"`
+	Memory               string `json:"memory"`
+	Name                 string `json:"name"`
+	Type                 string `json:"type"`
+	TenantID             string `json:"tenant_id"`
+	CloudProvider        string `json:"cloud_provider"`
+	CustomerManagedKeyID string `json:"customer_managed_key_id,omitempty"`
+	VectorOptimized      bool   `json:"vector_optimized,omitempty"`
+	GraphAnalyticsPlugin bool   `json:"graph_analytics_plugin,omitempty"`
+}
+
+// CloneInstanceRequest creates a new instance from the data of an existing
+// instance, or of one of its snapshots when SourceSnapshotID is set.
+type CloneInstanceRequest struct {
+	CreateInstanceRequest
+	SourceSnapshotID string `json:"source_snapshot_id,omitempty"`
+}
+
+// OverwriteInstanceRequest replaces the data of an instance with the data of
+// the source instance, or of one of its snapshots when SourceSnapshotID is set.
+// at least one of the two is required, a SourceSnapshotID on its own is a
+// snapshot of the overwritten instance.
+type OverwriteInstanceRequest struct {
+	SourceInstanceID string `json:"source_instance_id,omitempty"`
+	SourceSnapshotID string `json:"source_snapshot_id,omitempty"`
+}
+
+// Credentials holds the default neo4j user returned once, on instance creation.
+type Credentials struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type createInstanceResponse struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	TenantID      string `json:"tenant_id"`
+	CloudProvider string `json:"cloud_provider"`
+	Region        string `json:"region"`
+	Type          string `json:"type"`
+	ConnectionURL string `json:"connection_url"`
+	Credentials
+}
+
+// UpdateInstanceRequest only sends the fields that are set.
+type UpdateInstanceRequest struct {
+	Name              *string `json:"name,omitempty"`
+	Memory            *string `json:"memory,omitempty"`
+	SecondariesCount  *int64  `json:"secondaries_count,omitempty"`
+	CDCEnrichmentMode *string `json:"cdc_enrichment_mode,omitempty"`
+	// the api accepts graph_analytics_plugin as a "true"/"false" string
+	GraphAnalyticsPlugin *string `json:"graph_analytics_plugin,omitempty"`
+	VectorOptimized      *bool   `json:"vector_optimized,omitempty"`
+}
+
+func (i *Instance) validate() error {
+	if i.ID == "" {
+		return fmt.Errorf("instance response did not include an id")
+	}
+	if i.Status == "" {
+		return fmt.Errorf("instance %s response did not include a status", i.ID)
+	}
+	return nil
+}
+
+func (c *Client) GetInstances(ctx context.Context, tenant_id string) ([]InstanceSummary, error) {
+	var instances []InstanceSummary
+	if err := c.do(ctx, "GET", "/v1/instances?tenantId="+url.QueryEscape(tenant_id), nil, &instances); err != nil {
+		return nil, err
+	}
+	return instances, nil
+}
+
+func (c *Client) GetInstance(ctx context.Context, instance string) (*Instance, error) {
+	var resp Instance
+	if err := c.do(ctx, "GET", "/v1/instances/"+url.PathEscape(instance), nil, &resp); err != nil {
+		return nil, err
+	}
+	if err := resp.validate(); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// InstanceExists reports whether an instance with the given name exists in the tenant.
+func (c *Client) InstanceExists(ctx context.Context, tenant_id string, name string) (bool, error) {
+	instances, err := c.GetInstances(ctx, tenant_id)
+	if err != nil {
+		return false, fmt.Errorf("unable to list instances. are you using the correct tenant_id? %s: %v", tenant_id, err)
+	}
+	for _, instance := range instances {
+		if instance.Name == name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// CreateInstance creates the instance, waits for it to be running and returns
+// it together with the default neo4j user credentials.
+func (c *Client) CreateInstance(ctx context.Context, payload CreateInstanceRequest) (*Instance, *Credentials, error) {
+	tflog.Debug(ctx, fmt.Sprintf("create instance payload %+v.", payload))
+	return c.createInstance(ctx, "/v1/instances", payload.TenantID, payload.Name, payload)
+}
+
+// CloneInstance creates a new instance from the data of the source instance,
+// or of one of its snapshots, and waits for it like CreateInstance.
+func (c *Client) CloneInstance(ctx context.Context, source string, payload CloneInstanceRequest) (*Instance, *Credentials, error) {
+	tflog.Debug(ctx, fmt.Sprintf("clone instance %s payload %+v.", source, payload))
+	return c.createInstance(ctx, "/v1/instances/"+url.PathEscape(source)+"/clones", payload.TenantID, payload.Name, payload)
+}
+
+func (c *Client) createInstance(ctx context.Context, path string, tenant_id string, name string, payload any) (*Instance, *Credentials, error) {
+	exists, err := c.InstanceExists(ctx, tenant_id, name)
+	if err != nil {
+		return nil, nil, err
+	}
+	if exists {
+		return nil, nil, fmt.Errorf("instance %s already exists", name)
+	}
+
+	var created createInstanceResponse
+	if err := c.do(ctx, "POST", path, payload, &created); err != nil {
+		return nil, nil, err
+	}
+	if created.ID == "" {
+		return nil, nil, fmt.Errorf("create instance response did not include an id")
+	}
+
+	instance, err := c.WaitForInstance(ctx, created.ID, "create")
+	if err != nil {
+		return nil, nil, err
+	}
+	return instance, &created.Credentials, nil
+}
+
+func (c *Client) DeleteInstance(ctx context.Context, instance string) error {
+	if err := c.do(ctx, "DELETE", "/v1/instances/"+url.PathEscape(instance), nil, nil); err != nil {
+		return err
+	}
+	_, err := c.WaitForInstance(ctx, instance, "delete")
+	return err
+}
+
+func (c *Client) PauseInstance(ctx context.Context, instance string, wait bool) (*Instance, error) {
+	return c.instanceAction(ctx, instance, "pause", wait)
+}
+
+func (c *Client) ResumeInstance(ctx context.Context, instance string, wait bool) (*Instance, error) {
+	return c.instanceAction(ctx, instance, "resume", wait)
+}
+
+// instanceAction posts a pause or resume action. without wait the returned
+// instance is the (possibly partial) response of the action itself.
+func (c *Client) instanceAction(ctx context.Context, instance string, action string, wait bool) (*Instance, error) {
+	tflog.Info(ctx, fmt.Sprintf("running %s on instance %s, wait: %t.", action, instance, wait))
+	var resp Instance
+	if err := c.do(ctx, "POST", "/v1/instances/"+url.PathEscape(instance)+"/"+action, nil, &resp); err != nil {
+		return nil, err
+	}
+	if !wait {
+		return &resp, nil
+	}
+	return c.WaitForInstance(ctx, instance, action)
+}
+
+// OverwriteInstance replaces the data of a running instance and waits for it
+// to be running again.
+func (c *Client) OverwriteInstance(ctx context.Context, instance string, payload OverwriteInstanceRequest) (*Instance, error) {
+	tflog.Info(ctx, fmt.Sprintf("overwriting instance %s from %+v.", instance, payload))
+	if err := c.do(ctx, "POST", "/v1/instances/"+url.PathEscape(instance)+"/overwrite", payload, nil); err != nil {
+		return nil, err
+	}
+	return c.WaitForInstance(ctx, instance, "overwrite")
+}
+
+// RenameInstance can be performed on running and paused instances and does not wait.
+func (c *Client) RenameInstance(ctx context.Context, instance string, name string) error {
+	return c.do(ctx, "PATCH", "/v1/instances/"+url.PathEscape(instance), UpdateInstanceRequest{Name: &name}, nil)
+}
+
+// UpdateInstance patches the instance and waits for the update to complete.
+func (c *Client) UpdateInstance(ctx context.Context, instance string, payload UpdateInstanceRequest, description string) (*Instance, error) {
+	tflog.Info(ctx, fmt.Sprintf("update instance %s: %s", instance, description))
+	if err := c.do(ctx, "PATCH", "/v1/instances/"+url.PathEscape(instance), payload, nil); err != nil {
+		return nil, fmt.Errorf("error updating %s for instance %s: %w", description, instance, err)
+	}
+	return c.WaitForInstance(ctx, instance, "update")
+}
+
+func (c *Client) UpdateSecondariesCount(ctx context.Context, instance string, secondaries int64) (*Instance, error) {
+	return c.UpdateInstance(ctx, instance, UpdateInstanceRequest{SecondariesCount: &secondaries}, "secondaries_count")
+}
+
+func (c *Client) UpdateMemory(ctx context.Context, instance string, memory string) (*Instance, error) {
+	return c.UpdateInstance(ctx, instance, UpdateInstanceRequest{Memory: &memory}, "memory")
+}
+
+func (c *Client) UpdateGraphAnalyticsPlugin(ctx context.Context, instance string, graph_analytics_plugin bool) (*Instance, error) {
+	enabled := strconv.FormatBool(graph_analytics_plugin)
+	return c.UpdateInstance(ctx, instance, UpdateInstanceRequest{GraphAnalyticsPlugin: &enabled}, "graph_analytics_plugin")
+}
+
+func (c *Client) UpdateCDCEnrichmentMode(ctx context.Context, instance string, cdc_enrichment_mode string) (*Instance, error) {
+	return c.UpdateInstance(ctx, instance, UpdateInstanceRequest{CDCEnrichmentMode: &cdc_enrichment_mode}, "cdc_enrichment_mode")
+}
+
+func (c *Client) UpdateVectorOptimization(ctx context.Context, instance string, vector_optimized bool) (*Instance, error) {
+	return c.UpdateInstance(ctx, instance, UpdateInstanceRequest{VectorOptimized: &vector_optimized}, "vector_optimized")
+}
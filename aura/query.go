@@ -0,0 +1,127 @@
+package aura
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// queryRequest is a statement for the neo4j query api, served by every
+// running database next to its discovery document.
+type queryRequest struct {
+	Statement  string            `json:"statement"`
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
+type queryResponse struct {
+	Errors []struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// queryError is an error the database answered a statement with.
+type queryError struct {
+	StatusCode int
+	Code       string
+	Message    string
+}
+
+func (e *queryError) Error() string {
+	return fmt.Sprintf("http %d: %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+// ChangePassword changes the password of user on a running instance, signing
+// in with the current password. the Aura API has no endpoint to reset the
+// password of the default user, the database itself can change it through the
+// query api of neo4j 5. the request is not retried, once it succeeded the
+// current password is gone. when the outcome is unclear, such as a timeout or
+// a lost response, the new password is tried before reporting the failure.
+func (c *Client) ChangePassword(ctx context.Context, connection_url string, user string, current string, password string) error {
+	version, err := c.DiscoverVersion(ctx, connection_url)
+	if err != nil {
+		return err
+	}
+	if version != "5" {
+		return fmt.Errorf("neo4j %s has no query api, the password can only be changed on neo4j 5 and later", version)
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("changing password of user %s on %s.", user, connection_url))
+	err = c.query(ctx, connection_url, user, current, queryRequest{
+		Statement:  "ALTER CURRENT USER SET PASSWORD FROM $current TO $password",
+		Parameters: map[string]string{"current": current, "password": password},
+	})
+	if err == nil {
+		return nil
+	}
+	var queryErr *queryError
+	if errors.As(err, &queryErr) && queryErr.Code != "Neo.ClientError.Security.Unauthorized" {
+		return err // refused by the database, the current password still holds
+	}
+	if changed, verifyErr := c.VerifyPassword(ctx, connection_url, user, password); verifyErr == nil && changed {
+		tflog.Warn(ctx, fmt.Sprintf("changing password of user %s failed with %s, but the new password signs in.", user, err))
+		return nil
+	}
+	return err
+}
+
+// VerifyPassword reports whether user signs in to a running instance with
+// password.
+func (c *Client) VerifyPassword(ctx context.Context, connection_url string, user string, password string) (bool, error) {
+	err := c.query(ctx, connection_url, user, password, queryRequest{Statement: "SHOW CURRENT USER"})
+	var queryErr *queryError
+	if errors.As(err, &queryErr) && queryErr.Code == "Neo.ClientError.Security.Unauthorized" {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// query runs a statement against the system database as user, it returns a
+// queryError when the database refused it.
+func (c *Client) query(ctx context.Context, connection_url string, user string, password string, statement queryRequest) error {
+	baseURL, err := databaseURL(connection_url)
+	if err != nil {
+		return err
+	}
+	queryURL := baseURL + "/db/system/query/v2"
+	body, err := json.Marshal(statement)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", queryURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(user, password)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	r, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("unable to reach %s: %w", queryURL, err)
+	}
+	defer r.Body.Close()
+	bodyBytes, err := io.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+
+	var resp queryResponse
+	_ = json.Unmarshal(bodyBytes, &resp)
+	if len(resp.Errors) > 0 {
+		return fmt.Errorf("%s returned %w", queryURL, &queryError{StatusCode: r.StatusCode, Code: resp.Errors[0].Code, Message: resp.Errors[0].Message})
+	}
+	if r.StatusCode != http.StatusOK && r.StatusCode != http.StatusAccepted {
+		return fmt.Errorf("%s returned http %d", queryURL, r.StatusCode)
+	}
+	return nil
+}
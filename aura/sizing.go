@@ -0,0 +1,33 @@
+package aura
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type SizingEstimateRequest struct {
+	NodeCount           int64    `json:"node_count"`
+	RelationshipCount   int64    `json:"relationship_count"`
+	InstanceType        string   `json:"instance_type"`
+	AlgorithmCategories []string `json:"algorithm_categories"`
+}
+
+type SizingEstimate struct {
+	DidExceedMaximum  bool   `json:"did_exceed_maximum"`
+	RecommendedSize   string `json:"recommended_size"`
+	MinRequiredMemory string `json:"min_required_memory"`
+}
+
+func (c *Client) SizingEstimate(ctx context.Context, payload SizingEstimateRequest) (*SizingEstimate, error) {
+	tflog.Info(ctx, fmt.Sprintf("running sizing estimate with node: %d, relationship: %d, type: %s, categories: %v", payload.NodeCount, payload.RelationshipCount, payload.InstanceType, payload.AlgorithmCategories))
+	if payload.AlgorithmCategories == nil {
+		payload.AlgorithmCategories = []string{}
+	}
+	var resp SizingEstimate
+	if err := c.do(ctx, "POST", "/v1/instances/sizing", payload, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -0,0 +1,83 @@
+package aura
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Snapshot is a backup of an instance. Aura takes Scheduled snapshots on its
+// own, AdHoc snapshots are taken on demand.
+type Snapshot struct {
+	SnapshotID string `json:"snapshot_id"`
+	InstanceID string `json:"instance_id"`
+	Profile    string `json:"profile"`
+	Status     string `json:"status"`
+	Timestamp  string `json:"timestamp"`
+	Exportable bool   `json:"exportable"`
+}
+
+type createSnapshotResponse struct {
+	SnapshotID string `json:"snapshot_id"`
+}
+
+func (s *Snapshot) validate() error {
+	if s.SnapshotID == "" {
+		return fmt.Errorf("snapshot response did not include a snapshot_id")
+	}
+	if s.Status == "" {
+		return fmt.Errorf("snapshot %s response did not include a status", s.SnapshotID)
+	}
+	return nil
+}
+
+// GetSnapshots lists the snapshots of an instance taken on date, formatted
+// YYYY-MM-DD. an empty date lists the snapshots of the current day.
+func (c *Client) GetSnapshots(ctx context.Context, instance string, date string) ([]Snapshot, error) {
+	path := "/v1/instances/" + url.PathEscape(instance) + "/snapshots"
+	if date != "" {
+		path = path + "?date=" + url.QueryEscape(date)
+	}
+	var snapshots []Snapshot
+	if err := c.do(ctx, "GET", path, nil, &snapshots); err != nil {
+		return nil, err
+	}
+	return snapshots, nil
+}
+
+func (c *Client) GetSnapshot(ctx context.Context, instance string, snapshot string) (*Snapshot, error) {
+	var resp Snapshot
+	if err := c.do(ctx, "GET", "/v1/instances/"+url.PathEscape(instance)+"/snapshots/"+url.PathEscape(snapshot), nil, &resp); err != nil {
+		return nil, err
+	}
+	if err := resp.validate(); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// CreateSnapshot takes an on demand snapshot of a running instance and waits
+// for it to complete.
+func (c *Client) CreateSnapshot(ctx context.Context, instance string) (*Snapshot, error) {
+	tflog.Info(ctx, fmt.Sprintf("taking snapshot of instance %s.", instance))
+	var created createSnapshotResponse
+	if err := c.do(ctx, "POST", "/v1/instances/"+url.PathEscape(instance)+"/snapshots", nil, &created); err != nil {
+		return nil, err
+	}
+	if created.SnapshotID == "" {
+		return nil, fmt.Errorf("create snapshot response did not include a snapshot_id")
+	}
+	return c.WaitForSnapshot(ctx, instance, created.SnapshotID, "create")
+}
+
+// RestoreSnapshot overwrites the data of a running instance with one of its
+// own snapshots and waits for the instance to be running again.
+func (c *Client) RestoreSnapshot(ctx context.Context, instance string, snapshot string) (*Instance, error) {
+	tflog.Info(ctx, fmt.Sprintf("restoring snapshot %s to instance %s.", snapshot, instance))
+	if err := c.do(ctx, "POST", "/v1/instances/"+url.PathEscape(instance)+"/snapshots/"+url.PathEscape(snapshot)+"/restore", nil, nil); err != nil {
+		return nil, err
+	}
+	return c.WaitForInstance(ctx, instance, "restore")
+}
@@ -0,0 +1,50 @@
+package aura
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// Tenant is a project and the instance configurations available to it.
+type Tenant struct {
+	ID                     string                  `json:"id"`
+	Name                   string                  `json:"name"`
+	InstanceConfigurations []InstanceConfiguration `json:"instance_configurations"`
+}
+
+// TenantSummary is a single entry of the /tenants listing.
+type TenantSummary struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type InstanceConfiguration struct {
+	CloudProvider string `json:"cloud_provider"`
+	Memory        string `json:"memory"`
+	Region        string `json:"region"`
+	RegionName    string `json:"region_name"`
+	Storage       string `json:"storage"`
+	Type          string `json:"type"`
+	Version       string `json:"version"`
+}
+
+// GetTenants lists every tenant the client credentials have access to.
+func (c *Client) GetTenants(ctx context.Context) ([]TenantSummary, error) {
+	var tenants []TenantSummary
+	if err := c.do(ctx, "GET", "/v1/tenants", nil, &tenants); err != nil {
+		return nil, err
+	}
+	return tenants, nil
+}
+
+func (c *Client) GetTenant(ctx context.Context, tenant_id string) (*Tenant, error) {
+	var resp Tenant
+	if err := c.do(ctx, "GET", "/v1/tenants/"+url.PathEscape(tenant_id), nil, &resp); err != nil {
+		return nil, err
+	}
+	if resp.ID == "" {
+		return nil, fmt.Errorf("tenant response did not include an id")
+	}
+	return &resp, nil
+}
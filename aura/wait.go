@@ -0,0 +1,172 @@
+package aura
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// WaitForInstance polls the instance until action has completed and returns
+// the last instance read. a completed delete returns a nil instance.
+func (c *Client) WaitForInstance(ctx context.Context, instanceid string, action string) (*Instance, error) {
+	var instance *Instance
+	err := c.waitForActionToComplete(ctx, instanceid, action, "instance", func() (string, error) {
+		var err error
+		instance, err = c.GetInstance(ctx, instanceid)
+		if err != nil {
+			return "", err
+		}
+		return instance.Status, nil
+	})
+	if err != nil || action == "delete" {
+		return nil, err
+	}
+	return instance, nil
+}
+
+// WaitForCMK polls the cmk until action has completed and returns the last
+// cmk read. a completed delete returns a nil cmk.
+func (c *Client) WaitForCMK(ctx context.Context, cmkid string, action string) (*CMK, error) {
+	var cmk *CMK
+	err := c.waitForActionToComplete(ctx, cmkid, action, "cmk", func() (string, error) {
+		var err error
+		cmk, err = c.GetCMK(ctx, cmkid)
+		if err != nil {
+			return "", err
+		}
+		return cmk.Status, nil
+	})
+	if err != nil || action == "delete" {
+		return nil, err
+	}
+	return cmk, nil
+}
+
+// WaitForSnapshot polls the snapshot until action has completed and returns the
+// last snapshot read. a failed snapshot is an error rather than a status to
+// wait out.
+func (c *Client) WaitForSnapshot(ctx context.Context, instanceid string, snapshotid string, action string) (*Snapshot, error) {
+	var snapshot *Snapshot
+	err := c.waitForActionToComplete(ctx, snapshotid, action, "snapshot", func() (string, error) {
+		var err error
+		snapshot, err = c.GetSnapshot(ctx, instanceid, snapshotid)
+		if err != nil {
+			return "", err
+		}
+		if snapshot.Status == "Failed" {
+			return "", fmt.Errorf("snapshot %s of instance %s failed", snapshotid, instanceid)
+		}
+		return snapshot.Status, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// WaitForDatabase polls the discovery document of an instance until its
+// database accepts connections, which can lag behind a new instance reporting
+// running. it returns the major version the database runs.
+func (c *Client) WaitForDatabase(ctx context.Context, connection_url string) (string, error) {
+	tflog.Info(ctx, fmt.Sprintf("waiting for database %s to accept connections.", connection_url))
+	for {
+		version, err := c.DiscoverVersion(ctx, connection_url)
+		if err == nil {
+			return version, nil
+		}
+		tflog.Debug(ctx, fmt.Sprintf("database %s not available yet - %s", connection_url, err))
+		if !sleep(ctx, c.pollInterval) {
+			return "", fmt.Errorf("gave up waiting for database %s to accept connections, last error %q: %w", connection_url, err, ctx.Err())
+		}
+	}
+}
+
+// waitForActionToComplete polls getStatus every poll interval until the action
+// has completed or ctx is done. the deadline of ctx is the operation timeout,
+// there is no other cap on how long an action may take. an action is only
+// considered complete once the status has moved away from the one first read.
+// an action that finished before the first read never changes status, it is
+// taken as complete after a couple of polls and the settle delay, so a status
+// read before the api picked up the request is not mistaken for completion.
+func (c *Client) waitForActionToComplete(ctx context.Context, objectid string, action string, object string, getStatus func() (string, error)) error {
+	tflog.Info(ctx, fmt.Sprintf("waiting for action %s on %s %s.", action, object, objectid))
+	initialStatus, lastStatus, changed := "", "", false
+	for tries := 0; ; tries++ {
+		status, err := getStatus()
+		if err != nil {
+			tflog.Debug(ctx, fmt.Sprintf("get %s %s - %s", object, objectid, err))
+			if action == "delete" && IsNotFound(err) {
+				return nil // delete completed, cannot lookup status
+			}
+			if ctx.Err() != nil {
+				return waitError(ctx, action, object, objectid, lastStatus)
+			}
+			return err
+		}
+		if tries == 0 {
+			initialStatus = status
+			tflog.Debug(ctx, fmt.Sprintf("Setting initial status: %s", initialStatus))
+		} else if status != lastStatus {
+			tflog.Debug(ctx, fmt.Sprintf("Status changed from %s to %s", lastStatus, status))
+		}
+		changed = changed || status != initialStatus
+		lastStatus = status
+
+		completed := (changed || tries >= 2) && isActionComplete(ctx, action, object, status)
+		tflog.Debug(ctx, fmt.Sprintf("current status: %s, complete: %t", status, completed))
+		if completed {
+			if !changed && c.settleDelay > 0 {
+				tflog.Debug(ctx, fmt.Sprintf("status never changed, waiting %s", c.settleDelay))
+				if !sleep(ctx, c.settleDelay) {
+					return waitError(ctx, action, object, objectid, lastStatus)
+				}
+			}
+			return nil
+		}
+		if !sleep(ctx, c.pollInterval) {
+			return waitError(ctx, action, object, objectid, lastStatus)
+		}
+	}
+}
+
+// sleep waits for d and reports false when ctx is done first.
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+func waitError(ctx context.Context, action string, object string, objectid string, status string) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("timed out waiting for %s of %s %s to complete, last status %q: %w", action, object, objectid, status, ctx.Err())
+	}
+	return fmt.Errorf("cancelled waiting for %s of %s %s to complete, last status %q: %w", action, object, objectid, status, ctx.Err())
+}
+
+func isActionComplete(ctx context.Context, action string, object string, status string) bool {
+	switch action {
+	case "pause":
+		return status == "paused"
+	case "resume", "create", "update", "restore", "overwrite":
+		if object == "cmk" {
+			return status == "ready"
+		}
+		if object == "snapshot" {
+			return status == "Completed"
+		}
+		return status == "running"
+	case "delete":
+		return !(status == "deleting" || status == "destroying" || status == "updating" || status == "pending")
+	default:
+		tflog.Warn(ctx, "assuming complete with action")
+		return true
+	}
+}
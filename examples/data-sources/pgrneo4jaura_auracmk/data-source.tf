@@ -0,0 +1,13 @@
+# look up a cmk by id
+data "pgrneo4jaura_auracmk" "by_id" {
+	id = "<YOUR CMK ID>"
+}
+
+# or by name, narrowed down to the key for the instances it should encrypt
+data "pgrneo4jaura_auracmk" "by_name" {
+	tenant_id = "<YOUR TENANT ID>"
+	name = "<YOUR CMK NAME>"
+	cloud_provider = "aws"
+	region = "us-east-1"
+	instance_type = "enterprise-db"
+}
@@ -0,0 +1,11 @@
+# every aws cmk for enterprise-db instances in us-east-1
+data "pgrneo4jaura_auracmks" "keys" {
+	tenant_id = "<YOUR TENANT ID>"
+	cloud_provider = "aws"
+	region = "us-east-1"
+	instance_type = "enterprise-db"
+}
+
+output "ready_cmk_ids" {
+	value = [for cmk in data.pgrneo4jaura_auracmks.keys.cmks : cmk.id if cmk.status == "ready"]
+}
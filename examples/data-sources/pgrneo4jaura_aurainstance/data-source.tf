@@ -0,0 +1,10 @@
+# look up an instance by id
+data "pgrneo4jaura_aurainstance" "by_id" {
+	id = "<YOUR INSTANCE ID>"
+}
+
+# or by name within a tenant
+data "pgrneo4jaura_aurainstance" "by_name" {
+	tenant_id = "<YOUR TENANT ID>"
+	name = "<YOUR INSTANCE NAME>"
+}
@@ -0,0 +1,11 @@
+# every running aws instance whose name starts with "prod-"
+data "pgrneo4jaura_aurainstances" "prod" {
+	tenant_id = "<YOUR TENANT ID>"
+	name_regex = "^prod-"
+	cloud_provider = "aws"
+	status = "running"
+}
+
+output "prod_connection_urls" {
+	value = { for instance in data.pgrneo4jaura_aurainstances.prod.instances : instance.name => instance.connection_url }
+}
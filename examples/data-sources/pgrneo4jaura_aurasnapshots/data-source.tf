@@ -0,0 +1,12 @@
+# the most recent completed snapshot of an instance over the last days
+data "pgrneo4jaura_aurasnapshots" "latest" {
+	instance_id = "<YOUR INSTANCE ID>"
+	from = "2025-01-01"
+	to = "2025-01-07"
+	status = "Completed"
+	most_recent = true
+}
+
+output "latest_snapshot_id" {
+	value = one(data.pgrneo4jaura_aurasnapshots.latest.ids)
+}
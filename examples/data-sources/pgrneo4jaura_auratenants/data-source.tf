@@ -0,0 +1,8 @@
+# resolve a tenant id by name
+data "pgrneo4jaura_auratenants" "tenant" {
+	name = "<YOUR TENANT NAME>"
+}
+
+data "pgrneo4jaura_auraprojects" "projects" {
+	tenant_id = data.pgrneo4jaura_auratenants.tenant.ids[0]
+}
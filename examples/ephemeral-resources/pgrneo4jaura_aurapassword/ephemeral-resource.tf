@@ -0,0 +1,21 @@
+# generate the initial neo4j user password without storing it, terraform 1.11
+# and later. it is only written to the instance and to the secrets backend.
+ephemeral "pgrneo4jaura_aurapassword" "neo4j" {}
+
+resource "aws_secretsmanager_secret_version" "neo4j" {
+  secret_id = "<YOUR SECRET ID>"
+  secret_string_wo = ephemeral.pgrneo4jaura_aurapassword.neo4j.password
+  secret_string_wo_version = 1
+}
+
+resource "pgrneo4jaura_aurainstance" "aura" {
+  tenant_id = "<YOUR TENANT ID>"
+  name = "<YOUR INSTANCE NAME>"
+  type = "enterprise-db"
+  version = "5"
+  cloud_provider = "aws"
+  region = "us-east-1"
+  memory = "4GB"
+  n4jpwd_wo = ephemeral.pgrneo4jaura_aurapassword.neo4j.password
+  n4jpwd_wo_version = 1
+}
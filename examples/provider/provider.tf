@@ -1,4 +1,5 @@
 provider "pgrneo4jaura" {
   client_id = "<YOUR CLIENT ID>" # or set environment variable PGRNEO4J_CLIENTID
   client_secret = "<YOUR CLIENT SECRET>" # or set environment variable PGRNEO4J_CLIENTSECERET
+  # api_url = "https://api.neo4j.io" # optional, or set environment variable PGRNEO4J_APIURL
 }
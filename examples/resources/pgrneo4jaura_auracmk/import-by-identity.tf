@@ -0,0 +1,9 @@
+# terraform 1.12 and later, adopt an existing cmk by its id. the tenant is
+# optional and checked against the cmk when given.
+import {
+  to = pgrneo4jaura_auracmk.cmk
+  identity = {
+    id        = "<cmk_id>"
+    tenant_id = "<tenant_id>"
+  }
+}
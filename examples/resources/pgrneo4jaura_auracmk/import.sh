@@ -0,0 +1,5 @@
+# import by cmk id
+terraform import pgrneo4jaura_auracmk.cmk <cmk_id>
+
+# or by name within a tenant
+terraform import pgrneo4jaura_auracmk.cmk <tenant_id>/<cmk_name>
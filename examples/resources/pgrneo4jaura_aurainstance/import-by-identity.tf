@@ -0,0 +1,8 @@
+# terraform 1.12 and later, adopt an existing instance by its id. pair with
+# terraform plan -generate-config-out=generated.tf to write its configuration.
+import {
+  to = pgrneo4jaura_aurainstance.instance
+  identity = {
+    id = "<instance_id>"
+  }
+}
@@ -0,0 +1,10 @@
+# import by instance id, the version is discovered from the running database
+# or taken from the tenant when it offers a single version for the instance.
+# no password is needed, n4jpwd reads N/A
+terraform import pgrneo4jaura_aurainstance.instance <instance_id>
+
+# the memory of a paused instance is inferred from its storage, the legacy
+# format passes it explicitly along with the version, and whether the default
+# neo4j user is included with its password. the password then ends up in the
+# shell history and in state, prefer importing by id.
+terraform import pgrneo4jaura_aurainstance.instance <instance_id>,<version>,<n4jusr>(,<n4jpwd>)(,<memory>)
@@ -13,5 +13,48 @@ resource "pgrneo4jaura_aurainstance" "aura" {
   vector_optimized = true
   graph_analytics_plugin = false
   secondary_count = 0
+  cdc_enrichment_mode = "OFF"
+  # change to rotate the neo4j user password without replacing the instance
+  password_rotation_trigger = "2024-01"
+
+  timeouts {
+    update = "2h"
+  }
 }
 
+# Clone an existing instance, optionally from one of its snapshots
+resource "pgrneo4jaura_aurainstance" "clone" {
+  tenant_id = "<YOUR TENANT ID>"
+  name = "<YOUR CLONE NAME>"
+  type = "enterprise-db"
+  version = "5"
+  cloud_provider = "aws"
+  region = "us-east-1"
+  memory = "4GB"
+  n4jusr = true
+  source_instance_id = pgrneo4jaura_aurainstance.aura.id
+  source_snapshot_id = "<OPTIONAL SNAPSHOT ID>"
+}
+
+# keep the neo4j user password out of state, terraform 1.11 and later. the
+# initial password is generated ephemerally and only written to the instance
+# and to the secrets backend, n4jpwd stays null.
+ephemeral "pgrneo4jaura_aurapassword" "neo4j" {}
+
+resource "aws_secretsmanager_secret_version" "neo4j" {
+  secret_id = "<YOUR SECRET ID>"
+  secret_string_wo = ephemeral.pgrneo4jaura_aurapassword.neo4j.password
+  secret_string_wo_version = 1
+}
+
+resource "pgrneo4jaura_aurainstance" "writeonly" {
+  tenant_id = "<YOUR TENANT ID>"
+  name = "<YOUR INSTANCE NAME>"
+  type = "enterprise-db"
+  version = "5"
+  cloud_provider = "aws"
+  region = "us-east-1"
+  memory = "4GB"
+  n4jpwd_wo = ephemeral.pgrneo4jaura_aurapassword.neo4j.password
+  n4jpwd_wo_version = 1
+}
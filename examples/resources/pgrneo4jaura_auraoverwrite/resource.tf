@@ -0,0 +1,12 @@
+# refresh dev from prod once a week, a new triggers value overwrites again
+resource "time_rotating" "weekly" {
+    rotation_days = 7
+}
+
+resource "pgrneo4jaura_auraoverwrite" "dev_from_prod" {
+    instance_id = "<YOUR DEV INSTANCE ID>"
+    source_instance_id = "<YOUR PROD INSTANCE ID>"
+    triggers = {
+        week = time_rotating.weekly.id
+    }
+}
@@ -0,0 +1,15 @@
+# restore an instance in place from one of its snapshots, the current data is
+# overwritten. pin the snapshot id, changing it or the triggers restores
+# again on apply.
+resource "pgrneo4jaura_aurarestore" "recovery" {
+    instance_id = "<YOUR INSTANCE ID>"
+    snapshot_id = "<YOUR SNAPSHOT ID>"
+
+    triggers = {
+        run = "1"
+    }
+
+    timeouts {
+        create = "2h"
+    }
+}
@@ -0,0 +1 @@
+terraform import pgrneo4jaura_aurasnapshot.snapshot <instance_id>/<snapshot_id>
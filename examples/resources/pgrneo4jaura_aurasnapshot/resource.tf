@@ -0,0 +1,16 @@
+# take a snapshot before a risky change, apply waits for it to complete
+resource "pgrneo4jaura_aurasnapshot" "before_resize" {
+    instance_id = "<YOUR INSTANCE ID>"
+}
+
+# take a new snapshot every day, a new triggers value replaces the resource
+resource "time_rotating" "daily" {
+    rotation_days = 1
+}
+
+resource "pgrneo4jaura_aurasnapshot" "daily" {
+    instance_id = "<YOUR INSTANCE ID>"
+    triggers = {
+        day = time_rotating.daily.id
+    }
+}
@@ -0,0 +1,235 @@
+package pgrneo4jaura
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"terraform-provider-pgrneo4jaura/aura"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource                     = &auraCMKDataSource{}
+	_ datasource.DataSourceWithConfigure        = &auraCMKDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &auraCMKDataSource{}
+)
+
+func NewAuraCMKDataSource() datasource.DataSource {
+	return &auraCMKDataSource{}
+}
+
+type auraCMKDataSource struct {
+	client *aura.Client
+}
+
+type auraCMKDataSourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+	TenantID      types.String `tfsdk:"tenant_id"`
+	CloudProvider types.String `tfsdk:"cloud_provider"`
+	Region        types.String `tfsdk:"region"`
+	InstanceType  types.String `tfsdk:"instance_type"`
+	KeyID         types.String `tfsdk:"key_id"`
+	Status        types.String `tfsdk:"status"`
+	Created       types.String `tfsdk:"created"`
+}
+
+func (r *auraCMKDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_auracmk"
+}
+
+func (r *auraCMKDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Data lookup for an existing Neo4j Aura Customer Managed Key (CMK) by id, or by name narrowed down by tenant, cloud provider, region and instance type",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Neo4j Aura CMK id. Either id or name must be set.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "Neo4j Aura CMK name. Either id or name must be set.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"tenant_id": schema.StringAttribute{
+				Description: "Neo4j Aura tenant identifier, the cmk must belong to it when set.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(36),
+					stringvalidator.LengthAtMost(36),
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`),
+						"must be a valid tenant id",
+					),
+				},
+			},
+			"cloud_provider": schema.StringAttribute{
+				Description: "Neo4j Aura CMK cloud provider, the cmk must match it when set.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf([]string{"gcp", "aws", "azure"}...),
+				},
+			},
+			"region": schema.StringAttribute{
+				Description: "Neo4j Aura CMK region, the cmk must match it when set.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"instance_type": schema.StringAttribute{
+				Description: "Neo4j Aura CMK type, the cmk must match it when set.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf([]string{"enterprise-db", "enterprise-ds", "professional-db", "professional-ds", "free-db"}...),
+				},
+			},
+			//computed
+			"key_id": schema.StringAttribute{
+				Description: "CMK key id.",
+				Computed:    true,
+			},
+			"status": schema.StringAttribute{
+				Description: "Neo4j Aura CMK status, only a ready cmk can be used by instances.",
+				Computed:    true,
+			},
+			"created": schema.StringAttribute{
+				Description: "Neo4j Aura CMK created at date/time.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *auraCMKDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("name"),
+		),
+	}
+}
+
+func (r *auraCMKDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(providerData).client
+}
+
+func (r *auraCMKDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state auraCMKDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the listing only carries id, name and tenant, the remaining filters need the cmk itself
+	matches := func(cmk *aura.CMK) bool {
+		return matchesFilter(state.TenantID, cmk.TenantID) &&
+			matchesFilter(state.CloudProvider, cmk.CloudProvider) &&
+			matchesFilter(state.Region, cmk.Region) &&
+			matchesFilter(state.InstanceType, cmk.InstanceType)
+	}
+
+	var cmk *aura.CMK
+	if id := state.ID.ValueString(); id != "" {
+		tflog.Info(ctx, fmt.Sprintf("reading neo4j cmk %s", id))
+		found, err := r.client.GetCMK(ctx, id)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Neo4j Aura CMK",
+				"Could not read Neo4j Aura CMK "+id+". Received error: "+err.Error(),
+			)
+			return
+		}
+		if !matches(found) {
+			resp.Diagnostics.AddError(
+				"Error Reading Neo4j Aura CMK",
+				fmt.Sprintf("Neo4j Aura CMK %s does not match the given filters, it is a %s %s cmk in %s of tenant %s.", id, found.CloudProvider, found.InstanceType, found.Region, found.TenantID),
+			)
+			return
+		}
+		cmk = found
+	} else {
+		name := state.Name.ValueString()
+		summaries, err := r.client.GetCMKs(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Neo4j Aura CMK",
+				"Could not retrieve list of Neo4j Aura CMKs. Received error: "+err.Error(),
+			)
+			return
+		}
+		var candidates []*aura.CMK
+		for _, summary := range summaries {
+			if summary.Name != name || !matchesFilter(state.TenantID, summary.TenantID) {
+				continue
+			}
+			found, err := r.client.GetCMK(ctx, summary.ID)
+			if aura.IsNotFound(err) {
+				tflog.Debug(ctx, fmt.Sprintf("cmk %s was deleted while listing", summary.ID))
+				continue
+			}
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error Reading Neo4j Aura CMK",
+					"Could not read Neo4j Aura CMK "+summary.ID+". Received error: "+err.Error(),
+				)
+				return
+			}
+			if matches(found) {
+				candidates = append(candidates, found)
+			}
+		}
+		if len(candidates) == 0 {
+			resp.Diagnostics.AddError(
+				"Error Reading Neo4j Aura CMK",
+				fmt.Sprintf("No Neo4j Aura CMK named %q matches the given filters.", name),
+			)
+			return
+		}
+		if len(candidates) > 1 {
+			var ids []string
+			for _, candidate := range candidates {
+				ids = append(ids, candidate.TenantID+"/"+candidate.ID)
+			}
+			resp.Diagnostics.AddError(
+				"Error Reading Neo4j Aura CMK",
+				fmt.Sprintf("Found %d Neo4j Aura CMKs named %q: %s. Narrow the lookup down with tenant_id, cloud_provider, region or instance_type, or look the cmk up by id instead.", len(candidates), name, strings.Join(ids, ", ")),
+			)
+			return
+		}
+		cmk = candidates[0]
+	}
+	tflog.Debug(ctx, fmt.Sprintf("cmk details: %+v", cmk))
+
+	state.ID = types.StringValue(cmk.ID)
+	state.Name = types.StringValue(cmk.Name)
+	state.TenantID = types.StringValue(cmk.TenantID)
+	state.CloudProvider = types.StringValue(cmk.CloudProvider)
+	state.Region = types.StringValue(cmk.Region)
+	state.InstanceType = types.StringValue(cmk.InstanceType)
+	state.KeyID = types.StringValue(cmk.KeyID)
+	state.Status = types.StringValue(cmk.Status)
+	state.Created = optionalString(cmk.Created)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
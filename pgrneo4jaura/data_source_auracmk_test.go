@@ -0,0 +1,88 @@
+package pgrneo4jaura
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/compare"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccPGRNeo4jAuraCMKDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccCheckPGRNeo4jCMKDataSourceConfig(testAccTenantID),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.CompareValuePairs(
+						"data.pgrneo4jaura_auracmk.by_id",
+						tfjsonpath.New("id"),
+						"pgrneo4jaura_auracmk.cmk",
+						tfjsonpath.New("id"),
+						compare.ValuesSame(),
+					),
+					statecheck.CompareValuePairs(
+						"data.pgrneo4jaura_auracmk.by_name",
+						tfjsonpath.New("id"),
+						"pgrneo4jaura_auracmk.cmk",
+						tfjsonpath.New("id"),
+						compare.ValuesSame(),
+					),
+					statecheck.ExpectKnownValue(
+						"data.pgrneo4jaura_auracmk.by_id",
+						tfjsonpath.New("name"),
+						knownvalue.StringExact("mycmkds"),
+					),
+					statecheck.ExpectKnownValue(
+						"data.pgrneo4jaura_auracmk.by_name",
+						tfjsonpath.New("status"),
+						knownvalue.StringExact("ready"),
+					),
+					ExpectNotEmpty(
+						"data.pgrneo4jaura_auracmk.by_name",
+						tfjsonpath.New("created"),
+					),
+				},
+			},
+			{
+				Config: providerConfig + testAccCheckPGRNeo4jCMKDataSourceConfig(testAccTenantID) + `
+data "pgrneo4jaura_auracmk" "wrong_region" {
+	name = "mycmkds"
+	region = "eu-west-1"
+	depends_on = [pgrneo4jaura_auracmk.cmk]
+}`,
+				ExpectError: regexp.MustCompile(`No Neo4j Aura CMK named "mycmkds" matches`),
+			},
+		},
+	})
+}
+
+func testAccCheckPGRNeo4jCMKDataSourceConfig(tenant_id string) string {
+	return fmt.Sprintf(`
+	resource "pgrneo4jaura_auracmk" "cmk" {
+		tenant_id = "%s"
+		cloud_provider = "aws"
+		instance_type = "enterprise-db"
+		name = "mycmkds"
+		region = "us-east-1"
+		key_id = "arn:aws:kms:us-east-1:123456789012:key/mrk-ds"
+	}
+
+	data "pgrneo4jaura_auracmk" "by_id" {
+		id = pgrneo4jaura_auracmk.cmk.id
+	}
+
+	data "pgrneo4jaura_auracmk" "by_name" {
+		tenant_id = pgrneo4jaura_auracmk.cmk.tenant_id
+		name = "mycmkds"
+		cloud_provider = "aws"
+		region = "us-east-1"
+		instance_type = "enterprise-db"
+		depends_on = [pgrneo4jaura_auracmk.cmk]
+	}`, tenant_id)
+}
@@ -0,0 +1,274 @@
+package pgrneo4jaura
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"terraform-provider-pgrneo4jaura/aura"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource                   = &auraCMKsDataSource{}
+	_ datasource.DataSourceWithConfigure      = &auraCMKsDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &auraCMKsDataSource{}
+)
+
+// attributes of every entry in the cmks list
+var auraCMKAttrTypes = map[string]attr.Type{
+	"id":             types.StringType,
+	"name":           types.StringType,
+	"tenant_id":      types.StringType,
+	"cloud_provider": types.StringType,
+	"region":         types.StringType,
+	"instance_type":  types.StringType,
+	"key_id":         types.StringType,
+	"status":         types.StringType,
+	"created":        types.StringType,
+}
+
+func NewAuraCMKsDataSource() datasource.DataSource {
+	return &auraCMKsDataSource{}
+}
+
+type auraCMKsDataSource struct {
+	client *aura.Client
+}
+
+type auraCMKsDataSourceModel struct {
+	TenantID      types.String `tfsdk:"tenant_id"`
+	NameRegex     types.String `tfsdk:"name_regex"`
+	CloudProvider types.String `tfsdk:"cloud_provider"`
+	Region        types.String `tfsdk:"region"`
+	InstanceType  types.String `tfsdk:"instance_type"`
+	IDs           types.List   `tfsdk:"ids"`
+	CMKs          types.List   `tfsdk:"cmks"`
+}
+
+func (r *auraCMKsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_auracmks"
+}
+
+func (r *auraCMKsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Data lookup for Neo4j Aura Customer Managed Keys (CMK), optionally filtered",
+		Attributes: map[string]schema.Attribute{
+			"tenant_id": schema.StringAttribute{
+				Description: "Only return cmks of this tenant.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(36),
+					stringvalidator.LengthAtMost(36),
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`),
+						"must be a valid tenant id",
+					),
+				},
+			},
+			"name_regex": schema.StringAttribute{
+				Description: "Only return cmks whose name matches this regular expression.",
+				Optional:    true,
+			},
+			"cloud_provider": schema.StringAttribute{
+				Description: "Only return cmks in this cloud provider.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf([]string{"gcp", "aws", "azure"}...),
+				},
+			},
+			"region": schema.StringAttribute{
+				Description: "Only return cmks in this region.",
+				Optional:    true,
+			},
+			"instance_type": schema.StringAttribute{
+				Description: "Only return cmks for this instance type.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf([]string{"enterprise-db", "enterprise-ds", "professional-db", "professional-ds", "free-db"}...),
+				},
+			},
+			//computed
+			"ids": schema.ListAttribute{
+				Description: "Ids of the matching cmks.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"cmks": schema.ListNestedAttribute{
+				Description: "The matching cmks, ordered by name.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Neo4j Aura CMK id.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Neo4j Aura CMK name.",
+							Computed:    true,
+						},
+						"tenant_id": schema.StringAttribute{
+							Description: "Neo4j Aura tenant identifier.",
+							Computed:    true,
+						},
+						"cloud_provider": schema.StringAttribute{
+							Description: "Neo4j Aura CMK cloud provider.",
+							Computed:    true,
+						},
+						"region": schema.StringAttribute{
+							Description: "Neo4j Aura CMK region.",
+							Computed:    true,
+						},
+						"instance_type": schema.StringAttribute{
+							Description: "Neo4j Aura CMK type.",
+							Computed:    true,
+						},
+						"key_id": schema.StringAttribute{
+							Description: "CMK key id.",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "Neo4j Aura CMK status, only a ready cmk can be used by instances.",
+							Computed:    true,
+						},
+						"created": schema.StringAttribute{
+							Description: "Neo4j Aura CMK created at date/time.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *auraCMKsDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var config auraCMKsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if config.NameRegex.IsNull() || config.NameRegex.IsUnknown() {
+		return
+	}
+	if _, err := regexp.Compile(config.NameRegex.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name_regex"),
+			"Invalid name_regex",
+			"Could not compile name_regex. Received error: "+err.Error(),
+		)
+	}
+}
+
+func (r *auraCMKsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(providerData).client
+}
+
+func (r *auraCMKsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state auraCMKsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// ValidateConfig skips a name_regex that is only known at apply time
+	var nameRegex *regexp.Regexp
+	if !state.NameRegex.IsNull() {
+		var err error
+		nameRegex, err = regexp.Compile(state.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name_regex"),
+				"Invalid name_regex",
+				"Could not compile name_regex. Received error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	summaries, err := r.client.GetCMKs(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Neo4j Aura CMKs",
+			"Could not retrieve list of Neo4j Aura CMKs. Received error: "+err.Error(),
+		)
+		return
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Name == summaries[j].Name {
+			return summaries[i].ID < summaries[j].ID
+		}
+		return summaries[i].Name < summaries[j].Name
+	})
+
+	ids := []attr.Value{}
+	cmks := []attr.Value{}
+	for _, summary := range summaries {
+		// filter on the listing first so only candidates are read in full
+		if nameRegex != nil && !nameRegex.MatchString(summary.Name) {
+			continue
+		}
+		if !matchesFilter(state.TenantID, summary.TenantID) {
+			continue
+		}
+		cmk, err := r.client.GetCMK(ctx, summary.ID)
+		if aura.IsNotFound(err) {
+			tflog.Debug(ctx, fmt.Sprintf("cmk %s was deleted while listing", summary.ID))
+			continue
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Neo4j Aura CMKs",
+				"Could not read Neo4j Aura CMK "+summary.ID+". Received error: "+err.Error(),
+			)
+			return
+		}
+		if !matchesFilter(state.CloudProvider, cmk.CloudProvider) || !matchesFilter(state.Region, cmk.Region) || !matchesFilter(state.InstanceType, cmk.InstanceType) {
+			continue
+		}
+		object, diags := types.ObjectValue(auraCMKAttrTypes, map[string]attr.Value{
+			"id":             types.StringValue(cmk.ID),
+			"name":           types.StringValue(cmk.Name),
+			"tenant_id":      types.StringValue(cmk.TenantID),
+			"cloud_provider": types.StringValue(cmk.CloudProvider),
+			"region":         types.StringValue(cmk.Region),
+			"instance_type":  types.StringValue(cmk.InstanceType),
+			"key_id":         types.StringValue(cmk.KeyID),
+			"status":         types.StringValue(cmk.Status),
+			"created":        optionalString(cmk.Created),
+		})
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		ids = append(ids, types.StringValue(cmk.ID))
+		cmks = append(cmks, object)
+	}
+
+	state.IDs, diags = types.ListValue(types.StringType, ids)
+	resp.Diagnostics.Append(diags...)
+	state.CMKs, diags = types.ListValue(types.ObjectType{AttrTypes: auraCMKAttrTypes}, cmks)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
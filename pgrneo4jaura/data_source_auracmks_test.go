@@ -0,0 +1,108 @@
+package pgrneo4jaura
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccPGRNeo4jAuraCMKsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccCheckPGRNeo4jCMKsDataSourceConfig(testAccTenantID),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.pgrneo4jaura_auracmks.all",
+						tfjsonpath.New("ids"),
+						knownvalue.ListSizeExact(2),
+					),
+					statecheck.ExpectKnownValue(
+						"data.pgrneo4jaura_auracmks.eu",
+						tfjsonpath.New("cmks"),
+						knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.ObjectPartial(map[string]knownvalue.Check{
+								"name":   knownvalue.StringExact("mycmklist-b"),
+								"region": knownvalue.StringExact("eu-west-1"),
+								"status": knownvalue.StringExact("ready"),
+							}),
+						}),
+					),
+					statecheck.ExpectKnownValue(
+						"data.pgrneo4jaura_auracmks.none",
+						tfjsonpath.New("cmks"),
+						knownvalue.ListSizeExact(0),
+					),
+				},
+			},
+			{
+				Config: providerConfig + `
+data "pgrneo4jaura_auracmks" "invalid" {
+	name_regex = "("
+}`,
+				ExpectError: regexp.MustCompile(`Invalid name_regex`),
+			},
+			{
+				// output is unknown until applied, so only Read sees the pattern
+				Config: providerConfig + `
+resource "terraform_data" "pattern" {
+	input = "("
+}
+
+data "pgrneo4jaura_auracmks" "invalid" {
+	name_regex = terraform_data.pattern.output
+}`,
+				ExpectError: regexp.MustCompile(`Invalid name_regex`),
+			},
+		},
+	})
+}
+
+func testAccCheckPGRNeo4jCMKsDataSourceConfig(tenant_id string) string {
+	return fmt.Sprintf(`
+	resource "pgrneo4jaura_auracmk" "a" {
+		tenant_id = "%[1]s"
+		cloud_provider = "aws"
+		instance_type = "enterprise-db"
+		name = "mycmklist-a"
+		region = "us-east-1"
+		key_id = "arn:aws:kms:us-east-1:123456789012:key/mrk-list-a"
+	}
+
+	resource "pgrneo4jaura_auracmk" "b" {
+		tenant_id = "%[1]s"
+		cloud_provider = "aws"
+		instance_type = "enterprise-db"
+		name = "mycmklist-b"
+		region = "eu-west-1"
+		key_id = "arn:aws:kms:eu-west-1:123456789012:key/mrk-list-b"
+	}
+
+	data "pgrneo4jaura_auracmks" "all" {
+		tenant_id = "%[1]s"
+		name_regex = "^mycmklist-"
+		depends_on = [pgrneo4jaura_auracmk.a, pgrneo4jaura_auracmk.b]
+	}
+
+	data "pgrneo4jaura_auracmks" "eu" {
+		tenant_id = "%[1]s"
+		name_regex = "^mycmklist-"
+		cloud_provider = "aws"
+		region = "eu-west-1"
+		instance_type = "enterprise-db"
+		depends_on = [pgrneo4jaura_auracmk.a, pgrneo4jaura_auracmk.b]
+	}
+
+	data "pgrneo4jaura_auracmks" "none" {
+		tenant_id = "%[1]s"
+		name_regex = "^mycmklist-"
+		instance_type = "enterprise-ds"
+		depends_on = [pgrneo4jaura_auracmk.a, pgrneo4jaura_auracmk.b]
+	}`, tenant_id)
+}
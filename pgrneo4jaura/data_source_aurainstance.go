@@ -0,0 +1,251 @@
+package pgrneo4jaura
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"terraform-provider-pgrneo4jaura/aura"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource                     = &auraInstanceDataSource{}
+	_ datasource.DataSourceWithConfigure        = &auraInstanceDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &auraInstanceDataSource{}
+)
+
+func NewAuraInstanceDataSource() datasource.DataSource {
+	return &auraInstanceDataSource{}
+}
+
+type auraInstanceDataSource struct {
+	client *aura.Client
+}
+
+type auraInstanceDataSourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	Name              types.String `tfsdk:"name"`
+	TenantID          types.String `tfsdk:"tenant_id"`
+	Status            types.String `tfsdk:"status"`
+	Paused            types.Bool   `tfsdk:"paused"`
+	CloudProvider     types.String `tfsdk:"cloud_provider"`
+	Region            types.String `tfsdk:"region"`
+	InstanceType      types.String `tfsdk:"type"`
+	Memory            types.String `tfsdk:"memory"`
+	Storage           types.String `tfsdk:"storage"`
+	ConnectionURL     types.String `tfsdk:"connection_url"`
+	MetricsURL        types.String `tfsdk:"metrics_integration_url"`
+	CMK               types.String `tfsdk:"customer_managed_key_id"`
+	VectorOptimized   types.Bool   `tfsdk:"vector_optimized"`
+	GDSPlugin         types.Bool   `tfsdk:"graph_analytics_plugin"`
+	Secondaries       types.Int64  `tfsdk:"secondary_count"`
+	CDCEnrichmentMode types.String `tfsdk:"cdc_enrichment_mode"`
+}
+
+func (r *auraInstanceDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_aurainstance"
+}
+
+func (r *auraInstanceDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Data lookup for an existing Neo4j Aura instance by id, or by name within a tenant",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Neo4j Aura instance id. Either id or name must be set.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "Neo4j Aura instance name, requires tenant_id. Either id or name must be set.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("tenant_id")),
+				},
+			},
+			"tenant_id": schema.StringAttribute{
+				Description: "Neo4j Aura tenant identifier.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(36),
+					stringvalidator.LengthAtMost(36),
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`),
+						"must be a valid tenant id",
+					),
+				},
+			},
+			//computed
+			"status": schema.StringAttribute{
+				Description: "Neo4j Aura instance status.",
+				Computed:    true,
+			},
+			"paused": schema.BoolAttribute{
+				Description: "Neo4j instances running state.",
+				Computed:    true,
+			},
+			"cloud_provider": schema.StringAttribute{
+				Description: "Neo4j Aura instance cloud provider.",
+				Computed:    true,
+			},
+			"region": schema.StringAttribute{
+				Description: "Neo4j Aura instance region.",
+				Computed:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: "Neo4j Aura instance type.",
+				Computed:    true,
+			},
+			"memory": schema.StringAttribute{
+				Description: "Neo4j Aura instance memory size. Not reported while the instance is paused.",
+				Computed:    true,
+			},
+			"storage": schema.StringAttribute{
+				Description: "Neo4j Aura instance storage. Not reported while the instance is paused.",
+				Computed:    true,
+			},
+			"connection_url": schema.StringAttribute{
+				Description: "Neo4j Aura connection url.",
+				Computed:    true,
+			},
+			"metrics_integration_url": schema.StringAttribute{
+				Description: "Neo4j Aura instance metrics url.",
+				Computed:    true,
+			},
+			"customer_managed_key_id": schema.StringAttribute{
+				Description: "Neo4j Aura Customer Managed Key (CMK).",
+				Computed:    true,
+			},
+			"vector_optimized": schema.BoolAttribute{
+				Description: "Whether the instance is vector optimized.",
+				Computed:    true,
+			},
+			"graph_analytics_plugin": schema.BoolAttribute{
+				Description: "Whether the graph analytics plugin is enabled.",
+				Computed:    true,
+			},
+			"secondary_count": schema.Int64Attribute{
+				Description: "Number of secondary Neo4j Aura instances.",
+				Computed:    true,
+			},
+			"cdc_enrichment_mode": schema.StringAttribute{
+				Description: "Neo4j Aura instance change data capture enrichment mode.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *auraInstanceDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("name"),
+		),
+	}
+}
+
+func (r *auraInstanceDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(providerData).client
+}
+
+func (r *auraInstanceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state auraInstanceDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := state.ID.ValueString()
+	if id == "" {
+		name := state.Name.ValueString()
+		tenantID := state.TenantID.ValueString()
+		instances, err := r.client.GetInstances(ctx, tenantID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Neo4j Aura instance",
+				"Could not list Neo4j Aura instances in tenant "+tenantID+". Received error: "+err.Error(),
+			)
+			return
+		}
+		var matches []string
+		for _, instance := range instances {
+			if instance.Name == name {
+				matches = append(matches, instance.ID)
+			}
+		}
+		if len(matches) == 0 {
+			resp.Diagnostics.AddError(
+				"Error Reading Neo4j Aura instance",
+				fmt.Sprintf("No Neo4j Aura instance named %q exists in tenant %s.", name, tenantID),
+			)
+			return
+		}
+		if len(matches) > 1 {
+			resp.Diagnostics.AddError(
+				"Error Reading Neo4j Aura instance",
+				fmt.Sprintf("Found %d Neo4j Aura instances named %q in tenant %s: %s. Look the instance up by id instead.", len(matches), name, tenantID, strings.Join(matches, ", ")),
+			)
+			return
+		}
+		id = matches[0]
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("reading neo4j instance %s", id))
+	instance, err := r.client.GetInstance(ctx, id)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Neo4j Aura instance",
+			"Could not read Neo4j Aura instance "+id+". Received error: "+err.Error(),
+		)
+		return
+	}
+	tflog.Debug(ctx, fmt.Sprintf("instance details: %+v", instance))
+
+	state.ID = types.StringValue(instance.ID)
+	state.Name = types.StringValue(instance.Name)
+	state.TenantID = types.StringValue(instance.TenantID)
+	state.Status = types.StringValue(instance.Status)
+	state.Paused = types.BoolValue(instance.Status == "paused")
+	state.CloudProvider = types.StringValue(instance.CloudProvider)
+	state.Region = types.StringValue(instance.Region)
+	state.InstanceType = types.StringValue(instance.Type)
+	state.Memory = optionalString(instance.Memory)
+	state.Storage = optionalString(instance.Storage)
+	state.ConnectionURL = optionalString(instance.ConnectionURL)
+	state.MetricsURL = optionalString(instance.MetricsIntegrationURL)
+	state.CMK = optionalString(instance.CustomerManagedKeyID)
+	state.VectorOptimized = types.BoolValue(instance.VectorOptimized)
+	state.GDSPlugin = types.BoolValue(instance.GraphAnalyticsPlugin)
+	state.Secondaries = types.Int64Value(instance.SecondariesCount)
+	state.CDCEnrichmentMode = optionalString(instance.CDCEnrichmentMode)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// optionalString maps the empty string of an attribute the api left out to null.
+func optionalString(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
@@ -0,0 +1,132 @@
+package pgrneo4jaura
+
+import (
+	"fmt"
+	"regexp"
+	"terraform-provider-pgrneo4jaura/aura"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/compare"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccPGRNeo4jAuraInstanceDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccCheckPGRNeo4jInstanceDataSourceConfig(testAccTenantID),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.CompareValuePairs(
+						"data.pgrneo4jaura_aurainstance.by_id",
+						tfjsonpath.New("id"),
+						"pgrneo4jaura_aurainstance.instance",
+						tfjsonpath.New("id"),
+						compare.ValuesSame(),
+					),
+					statecheck.CompareValuePairs(
+						"data.pgrneo4jaura_aurainstance.by_name",
+						tfjsonpath.New("id"),
+						"pgrneo4jaura_aurainstance.instance",
+						tfjsonpath.New("id"),
+						compare.ValuesSame(),
+					),
+					statecheck.ExpectKnownValue(
+						"data.pgrneo4jaura_aurainstance.by_id",
+						tfjsonpath.New("name"),
+						knownvalue.StringExact("testproviderds"),
+					),
+					statecheck.ExpectKnownValue(
+						"data.pgrneo4jaura_aurainstance.by_name",
+						tfjsonpath.New("memory"),
+						knownvalue.StringExact("4GB"),
+					),
+					statecheck.ExpectKnownValue(
+						"data.pgrneo4jaura_aurainstance.by_name",
+						tfjsonpath.New("status"),
+						knownvalue.StringExact("running"),
+					),
+					ExpectNotEmpty(
+						"data.pgrneo4jaura_aurainstance.by_id",
+						tfjsonpath.New("connection_url"),
+					),
+					ExpectNotEmpty(
+						"data.pgrneo4jaura_aurainstance.by_id",
+						tfjsonpath.New("metrics_integration_url"),
+					),
+				},
+			},
+			{
+				Config: providerConfig + fmt.Sprintf(`
+data "pgrneo4jaura_aurainstance" "missing" {
+	tenant_id = "%s"
+	name = "testproviderds-missing"
+}`, testAccTenantID),
+				ExpectError: regexp.MustCompile(`No Neo4j Aura instance named`),
+			},
+		},
+	})
+}
+
+func TestAccPGRNeo4jAuraInstanceDataSourceAmbiguousName(t *testing.T) {
+	if testAccAuraServer == nil {
+		t.Skip("creating instances with the same name needs the fake Aura API")
+	}
+	t.Parallel()
+
+	// the api refuses a name already in use, seed the duplicates directly
+	for range 2 {
+		testAccAuraServer.AddInstance(aura.Instance{
+			Name:          "testproviderdsdup",
+			TenantID:      testAccTenantID,
+			CloudProvider: "aws",
+			Region:        "us-east-1",
+			Type:          "enterprise-db",
+			Memory:        "2GB",
+		})
+	}
+	t.Cleanup(func() {
+		testAccAuraServer.RemoveInstances("testproviderdsdup")
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + fmt.Sprintf(`
+data "pgrneo4jaura_aurainstance" "duplicate" {
+	tenant_id = "%s"
+	name = "testproviderdsdup"
+}`, testAccTenantID),
+				ExpectError: regexp.MustCompile(`Found 2 Neo4j Aura instances named "testproviderdsdup"`),
+			},
+		},
+	})
+}
+
+func testAccCheckPGRNeo4jInstanceDataSourceConfig(tenant_id string) string {
+	return fmt.Sprintf(`
+	resource "pgrneo4jaura_aurainstance" "instance" {
+		tenant_id = "%s"
+		name = "testproviderds"
+		type = "enterprise-db"
+		version = "5"
+		cloud_provider = "aws"
+		region = "us-east-1"
+		memory = "4GB"
+		paused = false
+		n4jusr = false
+	}
+
+	data "pgrneo4jaura_aurainstance" "by_id" {
+		id = pgrneo4jaura_aurainstance.instance.id
+	}
+
+	data "pgrneo4jaura_aurainstance" "by_name" {
+		tenant_id = pgrneo4jaura_aurainstance.instance.tenant_id
+		name = pgrneo4jaura_aurainstance.instance.name
+	}`, tenant_id)
+}
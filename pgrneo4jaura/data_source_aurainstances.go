@@ -0,0 +1,345 @@
+package pgrneo4jaura
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"terraform-provider-pgrneo4jaura/aura"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource                   = &auraInstancesDataSource{}
+	_ datasource.DataSourceWithConfigure      = &auraInstancesDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &auraInstancesDataSource{}
+)
+
+// attributes of every entry in the instances list
+var auraInstanceAttrTypes = map[string]attr.Type{
+	"id":                      types.StringType,
+	"name":                    types.StringType,
+	"tenant_id":               types.StringType,
+	"status":                  types.StringType,
+	"paused":                  types.BoolType,
+	"cloud_provider":          types.StringType,
+	"region":                  types.StringType,
+	"type":                    types.StringType,
+	"memory":                  types.StringType,
+	"storage":                 types.StringType,
+	"connection_url":          types.StringType,
+	"metrics_integration_url": types.StringType,
+	"customer_managed_key_id": types.StringType,
+	"vector_optimized":        types.BoolType,
+	"graph_analytics_plugin":  types.BoolType,
+	"secondary_count":         types.Int64Type,
+	"cdc_enrichment_mode":     types.StringType,
+	"created":                 types.StringType,
+}
+
+func NewAuraInstancesDataSource() datasource.DataSource {
+	return &auraInstancesDataSource{}
+}
+
+type auraInstancesDataSource struct {
+	client *aura.Client
+}
+
+type auraInstancesDataSourceModel struct {
+	TenantID      types.String `tfsdk:"tenant_id"`
+	NameRegex     types.String `tfsdk:"name_regex"`
+	CloudProvider types.String `tfsdk:"cloud_provider"`
+	Region        types.String `tfsdk:"region"`
+	InstanceType  types.String `tfsdk:"type"`
+	Status        types.String `tfsdk:"status"`
+	IDs           types.List   `tfsdk:"ids"`
+	Instances     types.List   `tfsdk:"instances"`
+}
+
+func (r *auraInstancesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_aurainstances"
+}
+
+func (r *auraInstancesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Data lookup for the Neo4j Aura instances of a tenant, optionally filtered",
+		Attributes: map[string]schema.Attribute{
+			"tenant_id": schema.StringAttribute{
+				Description: "Neo4j Aura tenant identifier.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(36),
+					stringvalidator.LengthAtMost(36),
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`),
+						"must be a valid tenant id",
+					),
+				},
+			},
+			"name_regex": schema.StringAttribute{
+				Description: "Only return instances whose name matches this regular expression.",
+				Optional:    true,
+			},
+			"cloud_provider": schema.StringAttribute{
+				Description: "Only return instances in this cloud provider.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf([]string{"gcp", "aws", "azure"}...),
+				},
+			},
+			"region": schema.StringAttribute{
+				Description: "Only return instances in this region.",
+				Optional:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: "Only return instances of this type.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf([]string{"enterprise-db", "enterprise-ds", "professional-db", "professional-ds", "free-db"}...),
+				},
+			},
+			"status": schema.StringAttribute{
+				Description: "Only return instances with this status, such as running or paused.",
+				Optional:    true,
+			},
+			//computed
+			"ids": schema.ListAttribute{
+				Description: "Ids of the matching instances.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"instances": schema.ListNestedAttribute{
+				Description: "The matching instances, ordered by name.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Neo4j Aura instance id.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Neo4j Aura instance name.",
+							Computed:    true,
+						},
+						"tenant_id": schema.StringAttribute{
+							Description: "Neo4j Aura tenant identifier.",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "Neo4j Aura instance status.",
+							Computed:    true,
+						},
+						"paused": schema.BoolAttribute{
+							Description: "Neo4j instances running state.",
+							Computed:    true,
+						},
+						"cloud_provider": schema.StringAttribute{
+							Description: "Neo4j Aura instance cloud provider.",
+							Computed:    true,
+						},
+						"region": schema.StringAttribute{
+							Description: "Neo4j Aura instance region.",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "Neo4j Aura instance type.",
+							Computed:    true,
+						},
+						"memory": schema.StringAttribute{
+							Description: "Neo4j Aura instance memory size. Not reported while the instance is paused.",
+							Computed:    true,
+						},
+						"storage": schema.StringAttribute{
+							Description: "Neo4j Aura instance storage. Not reported while the instance is paused.",
+							Computed:    true,
+						},
+						"connection_url": schema.StringAttribute{
+							Description: "Neo4j Aura connection url.",
+							Computed:    true,
+						},
+						"metrics_integration_url": schema.StringAttribute{
+							Description: "Neo4j Aura instance metrics url.",
+							Computed:    true,
+						},
+						"customer_managed_key_id": schema.StringAttribute{
+							Description: "Neo4j Aura Customer Managed Key (CMK).",
+							Computed:    true,
+						},
+						"vector_optimized": schema.BoolAttribute{
+							Description: "Whether the instance is vector optimized.",
+							Computed:    true,
+						},
+						"graph_analytics_plugin": schema.BoolAttribute{
+							Description: "Whether the graph analytics plugin is enabled.",
+							Computed:    true,
+						},
+						"secondary_count": schema.Int64Attribute{
+							Description: "Number of secondary Neo4j Aura instances.",
+							Computed:    true,
+						},
+						"cdc_enrichment_mode": schema.StringAttribute{
+							Description: "Neo4j Aura instance change data capture enrichment mode.",
+							Computed:    true,
+						},
+						"created": schema.StringAttribute{
+							Description: "Neo4j Aura instance created at date/time.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *auraInstancesDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var config auraInstancesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if config.NameRegex.IsNull() || config.NameRegex.IsUnknown() {
+		return
+	}
+	if _, err := regexp.Compile(config.NameRegex.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name_regex"),
+			"Invalid name_regex",
+			"Could not compile name_regex. Received error: "+err.Error(),
+		)
+	}
+}
+
+func (r *auraInstancesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(providerData).client
+}
+
+func (r *auraInstancesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state auraInstancesDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tenantID := state.TenantID.ValueString()
+	// ValidateConfig skips a name_regex that is only known at apply time
+	var nameRegex *regexp.Regexp
+	if !state.NameRegex.IsNull() {
+		var err error
+		nameRegex, err = regexp.Compile(state.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name_regex"),
+				"Invalid name_regex",
+				"Could not compile name_regex. Received error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	summaries, err := r.client.GetInstances(ctx, tenantID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Neo4j Aura instances",
+			"Could not list Neo4j Aura instances in tenant "+tenantID+". Received error: "+err.Error(),
+		)
+		return
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Name == summaries[j].Name {
+			return summaries[i].ID < summaries[j].ID
+		}
+		return summaries[i].Name < summaries[j].Name
+	})
+
+	// filter on the listing first so only candidates are read in full, the
+	// listing carries neither region, type nor status
+	candidates := []aura.InstanceSummary{}
+	for _, summary := range summaries {
+		if nameRegex != nil && !nameRegex.MatchString(summary.Name) {
+			continue
+		}
+		if !matchesFilter(state.CloudProvider, summary.CloudProvider) {
+			continue
+		}
+		candidates = append(candidates, summary)
+	}
+
+	ids := []attr.Value{}
+	instances := []attr.Value{}
+	for _, summary := range candidates {
+		instance, err := r.client.GetInstance(ctx, summary.ID)
+		if aura.IsNotFound(err) {
+			tflog.Debug(ctx, fmt.Sprintf("instance %s was deleted while listing", summary.ID))
+			continue
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Neo4j Aura instances",
+				"Could not read Neo4j Aura instance "+summary.ID+". Received error: "+err.Error(),
+			)
+			return
+		}
+		if !matchesFilter(state.Region, instance.Region) || !matchesFilter(state.InstanceType, instance.Type) || !matchesFilter(state.Status, instance.Status) {
+			continue
+		}
+		object, diags := types.ObjectValue(auraInstanceAttrTypes, map[string]attr.Value{
+			"id":                      types.StringValue(instance.ID),
+			"name":                    types.StringValue(instance.Name),
+			"tenant_id":               types.StringValue(instance.TenantID),
+			"status":                  types.StringValue(instance.Status),
+			"paused":                  types.BoolValue(instance.Status == "paused"),
+			"cloud_provider":          types.StringValue(instance.CloudProvider),
+			"region":                  types.StringValue(instance.Region),
+			"type":                    types.StringValue(instance.Type),
+			"memory":                  optionalString(instance.Memory),
+			"storage":                 optionalString(instance.Storage),
+			"connection_url":          optionalString(instance.ConnectionURL),
+			"metrics_integration_url": optionalString(instance.MetricsIntegrationURL),
+			"customer_managed_key_id": optionalString(instance.CustomerManagedKeyID),
+			"vector_optimized":        types.BoolValue(instance.VectorOptimized),
+			"graph_analytics_plugin":  types.BoolValue(instance.GraphAnalyticsPlugin),
+			"secondary_count":         types.Int64Value(instance.SecondariesCount),
+			"cdc_enrichment_mode":     optionalString(instance.CDCEnrichmentMode),
+			"created":                 optionalString(summary.Created),
+		})
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		ids = append(ids, types.StringValue(instance.ID))
+		instances = append(instances, object)
+	}
+
+	state.IDs, diags = types.ListValue(types.StringType, ids)
+	resp.Diagnostics.Append(diags...)
+	state.Instances, diags = types.ListValue(types.ObjectType{AttrTypes: auraInstanceAttrTypes}, instances)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// matchesFilter reports whether value passes an optional exact match filter.
+func matchesFilter(filter types.String, value string) bool {
+	return filter.IsNull() || filter.IsUnknown() || filter.ValueString() == value
+}
@@ -0,0 +1,117 @@
+package pgrneo4jaura
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccPGRNeo4jAuraInstancesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccCheckPGRNeo4jInstancesDataSourceConfig(testAccTenantID),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.pgrneo4jaura_aurainstances.running",
+						tfjsonpath.New("instances"),
+						knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.ObjectPartial(map[string]knownvalue.Check{
+								"name":   knownvalue.StringExact("testproviderlist-a"),
+								"status": knownvalue.StringExact("running"),
+								"memory": knownvalue.StringExact("2GB"),
+							}),
+						}),
+					),
+					statecheck.ExpectKnownValue(
+						"data.pgrneo4jaura_aurainstances.all",
+						tfjsonpath.New("ids"),
+						knownvalue.ListSizeExact(2),
+					),
+					statecheck.ExpectKnownValue(
+						"data.pgrneo4jaura_aurainstances.none",
+						tfjsonpath.New("instances"),
+						knownvalue.ListSizeExact(0),
+					),
+				},
+			},
+			{
+				Config: providerConfig + fmt.Sprintf(`
+data "pgrneo4jaura_aurainstances" "invalid" {
+	tenant_id = "%s"
+	name_regex = "("
+}`, testAccTenantID),
+				ExpectError: regexp.MustCompile(`Invalid name_regex`),
+			},
+			{
+				// output is unknown until applied, so only Read sees the pattern
+				Config: providerConfig + fmt.Sprintf(`
+resource "terraform_data" "pattern" {
+	input = "("
+}
+
+data "pgrneo4jaura_aurainstances" "invalid" {
+	tenant_id = "%s"
+	name_regex = terraform_data.pattern.output
+}`, testAccTenantID),
+				ExpectError: regexp.MustCompile(`Invalid name_regex`),
+			},
+		},
+	})
+}
+
+func testAccCheckPGRNeo4jInstancesDataSourceConfig(tenant_id string) string {
+	return fmt.Sprintf(`
+	resource "pgrneo4jaura_aurainstance" "a" {
+		tenant_id = "%[1]s"
+		name = "testproviderlist-a"
+		type = "enterprise-db"
+		version = "5"
+		cloud_provider = "aws"
+		region = "us-east-1"
+		memory = "2GB"
+		paused = false
+		n4jusr = false
+	}
+
+	resource "pgrneo4jaura_aurainstance" "b" {
+		tenant_id = "%[1]s"
+		name = "testproviderlist-b"
+		type = "enterprise-db"
+		version = "5"
+		cloud_provider = "aws"
+		region = "us-east-1"
+		memory = "2GB"
+		paused = true
+		n4jusr = false
+	}
+
+	data "pgrneo4jaura_aurainstances" "all" {
+		tenant_id = "%[1]s"
+		name_regex = "^testproviderlist-"
+		depends_on = [pgrneo4jaura_aurainstance.a, pgrneo4jaura_aurainstance.b]
+	}
+
+	data "pgrneo4jaura_aurainstances" "running" {
+		tenant_id = "%[1]s"
+		name_regex = "^testproviderlist-"
+		cloud_provider = "aws"
+		region = "us-east-1"
+		type = "enterprise-db"
+		status = "running"
+		depends_on = [pgrneo4jaura_aurainstance.a, pgrneo4jaura_aurainstance.b]
+	}
+
+	data "pgrneo4jaura_aurainstances" "none" {
+		tenant_id = "%[1]s"
+		name_regex = "^testproviderlist-"
+		region = "eu-west-1"
+		depends_on = [pgrneo4jaura_aurainstance.a, pgrneo4jaura_aurainstance.b]
+	}`, tenant_id)
+}
@@ -2,8 +2,8 @@ package pgrneo4jaura
 
 import (
 	"context"
-	"fmt"
 	"regexp"
+	"terraform-provider-pgrneo4jaura/aura"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -22,7 +22,7 @@ func NewAuraProjectsDataSource() datasource.DataSource {
 }
 
 type auraProjectsDataSource struct {
-	access_token string
+	client *aura.Client
 }
 
 type auraProjectsDataSourceModel struct {
@@ -79,7 +79,7 @@ func (r *auraProjectsDataSource) Configure(_ context.Context, req datasource.Con
 		return
 	}
 
-	r.access_token = req.ProviderData.(providerData).access_token
+	r.client = req.ProviderData.(providerData).client
 }
 
 func (r *auraProjectsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -93,7 +93,7 @@ func (r *auraProjectsDataSource) Read(ctx context.Context, req datasource.ReadRe
 	tenantId := state.TenantID.ValueString()
 
 	// Fetch project configurations
-	projectConfigurations, err := neo4jGetProjectConfigurations(r.access_token, tenantId)
+	projectConfigurations, err := r.client.GetTenant(ctx, tenantId)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Get Neo4j Aura project configurations.",
@@ -102,29 +102,10 @@ func (r *auraProjectsDataSource) Read(ctx context.Context, req datasource.ReadRe
 		return
 	}
 
-	// Extract instance configurations from the response
-	instanceConfigurationsRaw, ok := projectConfigurations["data"].(map[string]interface{})["instance_configurations"].([]interface{})
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Invalid Response",
-			"'instance_configurations' is not in the expected format.",
-		)
-		return
-	}
-
 	// Convert instance configurations to types.Set
 	var instanceConfigurations []attr.Value
-	for _, rawConfig := range instanceConfigurationsRaw {
-		configMap, ok := rawConfig.(map[string]interface{})
-		if !ok {
-			resp.Diagnostics.AddError(
-				"Invalid Configuration",
-				"One of the instance configurations is not a valid map.",
-			)
-			return
-		}
-
-		// Convert each map[string]interface{} to types.Object
+	for _, config := range projectConfigurations.InstanceConfigurations {
+		// Convert each configuration to types.Object
 		instanceConfig, err := types.ObjectValue(map[string]attr.Type{
 			"cloud_provider": types.StringType,
 			"memory":         types.StringType,
@@ -134,13 +115,13 @@ func (r *auraProjectsDataSource) Read(ctx context.Context, req datasource.ReadRe
 			"type":           types.StringType,
 			"version":        types.StringType,
 		}, map[string]attr.Value{
-			"cloud_provider": types.StringValue(configMap["cloud_provider"].(string)),
-			"memory":         types.StringValue(configMap["memory"].(string)),
-			"region":         types.StringValue(configMap["region"].(string)),
-			"region_name":    types.StringValue(configMap["region_name"].(string)),
-			"storage":        types.StringValue(configMap["storage"].(string)),
-			"type":           types.StringValue(configMap["type"].(string)),
-			"version":        types.StringValue(configMap["version"].(string)),
+			"cloud_provider": types.StringValue(config.CloudProvider),
+			"memory":         types.StringValue(config.Memory),
+			"region":         types.StringValue(config.Region),
+			"region_name":    types.StringValue(config.RegionName),
+			"storage":        types.StringValue(config.Storage),
+			"type":           types.StringValue(config.Type),
+			"version":        types.StringValue(config.Version),
 		})
 		if err != nil {
 			resp.Diagnostics.AddError(
@@ -176,7 +157,7 @@ func (r *auraProjectsDataSource) Read(ctx context.Context, req datasource.ReadRe
 
 	// Set other state attributes
 	state.TenantID = types.StringValue(tenantId)
-	state.Name = types.StringValue(fmt.Sprintf("%v", projectConfigurations["data"].(map[string]interface{})["name"]))
+	state.Name = types.StringValue(projectConfigurations.Name)
 
 	// Save state
 	diags = resp.State.Set(ctx, &state)
@@ -1,6 +1,7 @@
 package pgrneo4jaura
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -14,10 +15,10 @@ func TestAccPGRNeo4jAuraProjectConfigurations(t *testing.T) {
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
-				Config: providerConfig + `
+				Config: providerConfig + fmt.Sprintf(`
 data "pgrneo4jaura_auraprojects" "projects" {
-	tenant_id = "00000000-0000-0000-0000-000000000000"
-}`,
+	tenant_id = "%s"
+}`, testAccTenantID),
 				ConfigStateChecks: []statecheck.StateCheck{
 					ExpectNotEmpty(
 						"data.pgrneo4jaura_auraprojects.projects",
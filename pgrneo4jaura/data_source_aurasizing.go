@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"terraform-provider-pgrneo4jaura/aura"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -20,7 +21,7 @@ func NewAuraSizingEstimateDataSource() datasource.DataSource {
 }
 
 type auraSizingEstimateDataSource struct {
-	access_token string
+	client *aura.Client
 }
 
 type auraSizingEstimateDataSourceModel struct {
@@ -80,7 +81,7 @@ func (r *auraSizingEstimateDataSource) Configure(_ context.Context, req datasour
 		return
 	}
 
-	r.access_token = req.ProviderData.(providerData).access_token
+	r.client = req.ProviderData.(providerData).client
 }
 
 func (r *auraSizingEstimateDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -120,7 +121,12 @@ func (r *auraSizingEstimateDataSource) Read(ctx context.Context, req datasource.
 		}
 	}
 
-	estimate, err := neo4jSizingEstimate(ctx, r.access_token, nodeCount, relationshipCount, instanceType, stringCategories)
+	estimate, err := r.client.SizingEstimate(ctx, aura.SizingEstimateRequest{
+		NodeCount:           nodeCount,
+		RelationshipCount:   relationshipCount,
+		InstanceType:        instanceType,
+		AlgorithmCategories: stringCategories,
+	})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Get Neo4j Aura instance sizing estimate.",
@@ -129,10 +135,10 @@ func (r *auraSizingEstimateDataSource) Read(ctx context.Context, req datasource.
 		return
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("estimate: %v", estimate))
-	state.DidExceedMaximum = types.BoolValue(estimate["data"].(map[string]interface{})["did_exceed_maximum"].(bool))
-	state.RecommendedSize = types.StringValue(estimate["data"].(map[string]interface{})["recommended_size"].(string))
-	state.MinRequiredMemory = types.StringValue(estimate["data"].(map[string]interface{})["min_required_memory"].(string))
+	tflog.Debug(ctx, fmt.Sprintf("estimate: %+v", estimate))
+	state.DidExceedMaximum = types.BoolValue(estimate.DidExceedMaximum)
+	state.RecommendedSize = types.StringValue(estimate.RecommendedSize)
+	state.MinRequiredMemory = types.StringValue(estimate.MinRequiredMemory)
 
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -0,0 +1,322 @@
+package pgrneo4jaura
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"terraform-provider-pgrneo4jaura/aura"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource                   = &auraSnapshotsDataSource{}
+	_ datasource.DataSourceWithConfigure      = &auraSnapshotsDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &auraSnapshotsDataSource{}
+)
+
+// snapshots are listed one day at a time, a range is capped so a typo in a
+// year does not turn into thousands of requests.
+const maxSnapshotDays = 90
+
+// attributes of every entry in the snapshots list
+var auraSnapshotAttrTypes = map[string]attr.Type{
+	"snapshot_id": types.StringType,
+	"instance_id": types.StringType,
+	"profile":     types.StringType,
+	"status":      types.StringType,
+	"timestamp":   types.StringType,
+	"exportable":  types.BoolType,
+}
+
+func NewAuraSnapshotsDataSource() datasource.DataSource {
+	return &auraSnapshotsDataSource{}
+}
+
+type auraSnapshotsDataSource struct {
+	client *aura.Client
+}
+
+type auraSnapshotsDataSourceModel struct {
+	InstanceID types.String `tfsdk:"instance_id"`
+	SnapshotID types.String `tfsdk:"snapshot_id"`
+	MostRecent types.Bool   `tfsdk:"most_recent"`
+	From       types.String `tfsdk:"from"`
+	To         types.String `tfsdk:"to"`
+	Profile    types.String `tfsdk:"profile"`
+	Status     types.String `tfsdk:"status"`
+	IDs        types.List   `tfsdk:"ids"`
+	Snapshots  types.List   `tfsdk:"snapshots"`
+}
+
+func (r *auraSnapshotsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_aurasnapshots"
+}
+
+func (r *auraSnapshotsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	dateValidators := []validator.String{
+		stringvalidator.RegexMatches(regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`), "must be a date formatted YYYY-MM-DD"),
+		stringvalidator.ConflictsWith(path.MatchRoot("snapshot_id")),
+	}
+	resp.Schema = schema.Schema{
+		Description: "Data lookup for the snapshots of a Neo4j Aura instance, by id, or by date range optionally narrowed down to the most recent",
+		Attributes: map[string]schema.Attribute{
+			"instance_id": schema.StringAttribute{
+				Description: "Neo4j Aura instance id.",
+				Required:    true,
+			},
+			"snapshot_id": schema.StringAttribute{
+				Description: "Only return the snapshot with this id.",
+				Optional:    true,
+			},
+			"most_recent": schema.BoolAttribute{
+				Description: "Only return the most recent of the matching snapshots. Only completed snapshots are considered unless status is set.",
+				Optional:    true,
+			},
+			"from": schema.StringAttribute{
+				Description: fmt.Sprintf("First day, formatted YYYY-MM-DD, to return snapshots of. Defaults to to. A range spans at most %d days.", maxSnapshotDays),
+				Optional:    true,
+				Validators:  dateValidators,
+			},
+			"to": schema.StringAttribute{
+				Description: "Last day, formatted YYYY-MM-DD, to return snapshots of. Defaults to the current day (UTC).",
+				Optional:    true,
+				Validators:  dateValidators,
+			},
+			"profile": schema.StringAttribute{
+				Description: "Only return snapshots of this profile, Scheduled or AdHoc.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf([]string{"Scheduled", "AdHoc"}...),
+				},
+			},
+			"status": schema.StringAttribute{
+				Description: "Only return snapshots with this status, such as Completed. Defaults to Completed when most_recent is set.",
+				Optional:    true,
+			},
+			//computed
+			"ids": schema.ListAttribute{
+				Description: "Ids of the matching snapshots.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"snapshots": schema.ListNestedAttribute{
+				Description: "The matching snapshots, most recent first.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"snapshot_id": schema.StringAttribute{
+							Description: "Neo4j Aura snapshot id.",
+							Computed:    true,
+						},
+						"instance_id": schema.StringAttribute{
+							Description: "Neo4j Aura instance id.",
+							Computed:    true,
+						},
+						"profile": schema.StringAttribute{
+							Description: "Scheduled for snapshots taken by Aura, AdHoc for snapshots taken on demand.",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "Neo4j Aura snapshot status.",
+							Computed:    true,
+						},
+						"timestamp": schema.StringAttribute{
+							Description: "Neo4j Aura snapshot date/time.",
+							Computed:    true,
+						},
+						"exportable": schema.BoolAttribute{
+							Description: "Whether the snapshot can be exported.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *auraSnapshotsDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var config auraSnapshotsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if config.From.IsUnknown() || config.To.IsUnknown() {
+		// the range is checked by Read once both dates are known
+		if !config.From.IsUnknown() && !config.From.IsNull() {
+			_, diags := snapshotDay("from", config.From.ValueString())
+			resp.Diagnostics.Append(diags...)
+		}
+		if !config.To.IsUnknown() && !config.To.IsNull() {
+			_, diags := snapshotDay("to", config.To.ValueString())
+			resp.Diagnostics.Append(diags...)
+		}
+		return
+	}
+	_, _, diags := snapshotDays(config.From.ValueString(), config.To.ValueString())
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *auraSnapshotsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(providerData).client
+}
+
+func (r *auraSnapshotsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state auraSnapshotsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	instanceID := state.InstanceID.ValueString()
+	var snapshots []aura.Snapshot
+	if !state.SnapshotID.IsNull() {
+		snapshotID := state.SnapshotID.ValueString()
+		tflog.Info(ctx, fmt.Sprintf("reading snapshot %s of neo4j instance %s", snapshotID, instanceID))
+		snapshot, err := r.client.GetSnapshot(ctx, instanceID, snapshotID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Neo4j Aura snapshots",
+				"Could not read snapshot "+snapshotID+" of Neo4j Aura instance "+instanceID+". Received error: "+err.Error(),
+			)
+			return
+		}
+		snapshots = append(snapshots, *snapshot)
+	} else {
+		// checked again, ValidateConfig skips dates only known at apply time
+		from, to, diags := snapshotDays(state.From.ValueString(), state.To.ValueString())
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+			date := day.Format(time.DateOnly)
+			tflog.Info(ctx, fmt.Sprintf("listing snapshots of neo4j instance %s on %s", instanceID, date))
+			listed, err := r.client.GetSnapshots(ctx, instanceID, date)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error Reading Neo4j Aura snapshots",
+					"Could not list snapshots of Neo4j Aura instance "+instanceID+" on "+date+". Received error: "+err.Error(),
+				)
+				return
+			}
+			snapshots = append(snapshots, listed...)
+		}
+	}
+	// RFC 3339 timestamps in UTC sort chronologically as strings
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[i].Timestamp > snapshots[j].Timestamp
+	})
+
+	// the most recent snapshot is meant to be restored or cloned, skip the
+	// ones still in progress or failed unless a status is asked for
+	status := state.Status
+	if state.MostRecent.ValueBool() && status.IsNull() {
+		status = types.StringValue("Completed")
+	}
+	ids := []attr.Value{}
+	objects := []attr.Value{}
+	for _, snapshot := range snapshots {
+		if !matchesFilter(state.Profile, snapshot.Profile) || !matchesFilter(status, snapshot.Status) {
+			continue
+		}
+		object, diags := types.ObjectValue(auraSnapshotAttrTypes, map[string]attr.Value{
+			"snapshot_id": types.StringValue(snapshot.SnapshotID),
+			"instance_id": types.StringValue(snapshot.InstanceID),
+			"profile":     types.StringValue(snapshot.Profile),
+			"status":      types.StringValue(snapshot.Status),
+			"timestamp":   types.StringValue(snapshot.Timestamp),
+			"exportable":  types.BoolValue(snapshot.Exportable),
+		})
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		ids = append(ids, types.StringValue(snapshot.SnapshotID))
+		objects = append(objects, object)
+		if state.MostRecent.ValueBool() {
+			break
+		}
+	}
+
+	state.IDs, diags = types.ListValue(types.StringType, ids)
+	resp.Diagnostics.Append(diags...)
+	state.Snapshots, diags = types.ListValue(types.ObjectType{AttrTypes: auraSnapshotAttrTypes}, objects)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// snapshotDays parses the from and to dates of a snapshot lookup and checks
+// the range they span. to defaults to the current day in UTC and from
+// defaults to to. errors are attached to the date at fault, or to both when
+// the range itself is invalid.
+func snapshotDays(from string, to string) (time.Time, time.Time, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	toDay := time.Now().UTC().Truncate(24 * time.Hour)
+	if to != "" {
+		day, dayDiags := snapshotDay("to", to)
+		diags.Append(dayDiags...)
+		toDay = day
+	}
+	fromDay := toDay
+	if from != "" {
+		day, dayDiags := snapshotDay("from", from)
+		diags.Append(dayDiags...)
+		fromDay = day
+	}
+	if diags.HasError() {
+		return time.Time{}, time.Time{}, diags
+	}
+
+	rangeError := ""
+	if toDay.Before(fromDay) {
+		rangeError = "from must not be after to"
+	} else if days := int(toDay.Sub(fromDay).Hours()/24) + 1; days > maxSnapshotDays {
+		rangeError = fmt.Sprintf("the range spans %d days, at most %d are allowed", days, maxSnapshotDays)
+	}
+	if rangeError != "" {
+		for _, attribute := range []string{"from", "to"} {
+			diags.AddAttributeError(path.Root(attribute), "Invalid snapshot date range", rangeError)
+		}
+		return time.Time{}, time.Time{}, diags
+	}
+	return fromDay, toDay, nil
+}
+
+// snapshotDay parses the date of attribute, from or to.
+func snapshotDay(attribute string, date string) (time.Time, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	day, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root(attribute),
+			"Invalid snapshot date",
+			fmt.Sprintf("%s %q is not a date formatted YYYY-MM-DD", attribute, date),
+		)
+	}
+	return day, diags
+}
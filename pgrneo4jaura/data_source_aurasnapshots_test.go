@@ -0,0 +1,169 @@
+package pgrneo4jaura
+
+import (
+	"fmt"
+	"regexp"
+	"terraform-provider-pgrneo4jaura/aura"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/compare"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccPGRNeo4jAuraSnapshotsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccCheckPGRNeo4jSnapshotConfig(testAccTenantID) + `
+	data "pgrneo4jaura_aurasnapshots" "latest_adhoc" {
+		instance_id = pgrneo4jaura_aurasnapshot.snapshot.instance_id
+		profile = "AdHoc"
+		most_recent = true
+	}
+
+	data "pgrneo4jaura_aurasnapshots" "by_id" {
+		instance_id = pgrneo4jaura_aurasnapshot.snapshot.instance_id
+		snapshot_id = pgrneo4jaura_aurasnapshot.snapshot.id
+	}
+
+	data "pgrneo4jaura_aurasnapshots" "none" {
+		instance_id = pgrneo4jaura_aurasnapshot.snapshot.instance_id
+		from = "2020-01-01"
+		to = "2020-01-31"
+	}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.CompareValuePairs(
+						"data.pgrneo4jaura_aurasnapshots.latest_adhoc",
+						tfjsonpath.New("ids").AtSliceIndex(0),
+						"pgrneo4jaura_aurasnapshot.snapshot",
+						tfjsonpath.New("id"),
+						compare.ValuesSame(),
+					),
+					statecheck.ExpectKnownValue(
+						"data.pgrneo4jaura_aurasnapshots.by_id",
+						tfjsonpath.New("snapshots"),
+						knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.ObjectPartial(map[string]knownvalue.Check{
+								"profile": knownvalue.StringExact("AdHoc"),
+								"status":  knownvalue.StringExact("Completed"),
+							}),
+						}),
+					),
+					statecheck.ExpectKnownValue(
+						"data.pgrneo4jaura_aurasnapshots.none",
+						tfjsonpath.New("snapshots"),
+						knownvalue.ListSizeExact(0),
+					),
+				},
+			},
+			{
+				Config: providerConfig + `
+data "pgrneo4jaura_aurasnapshots" "invalid" {
+	instance_id = "00000000"
+	from = "2024-02-01"
+	to = "2024-01-01"
+}`,
+				ExpectError: regexp.MustCompile(`from must not be after to`),
+			},
+			{
+				// output is unknown until applied, so only Read sees the range
+				Config: providerConfig + `
+resource "terraform_data" "to" {
+	input = "2024-01-01"
+}
+
+data "pgrneo4jaura_aurasnapshots" "invalid" {
+	instance_id = "00000000"
+	from = "2024-02-01"
+	to = terraform_data.to.output
+}`,
+				ExpectError: regexp.MustCompile(`from must not be after to`),
+			},
+			{
+				// the known date is checked on its own while the other is unknown
+				Config: providerConfig + `
+resource "terraform_data" "from" {
+	input = "2024-01-01"
+}
+
+data "pgrneo4jaura_aurasnapshots" "invalid" {
+	instance_id = "00000000"
+	from = terraform_data.from.output
+	to = "2024-13-01"
+}`,
+				ExpectError: regexp.MustCompile(`to "2024-13-01" is not a date formatted YYYY-MM-DD`),
+			},
+		},
+	})
+}
+
+func TestAccPGRNeo4jAuraSnapshotsDataSourceMostRecent(t *testing.T) {
+	if testAccAuraServer == nil {
+		t.Skip("seeding snapshots in progress needs the fake Aura API")
+	}
+	t.Parallel()
+
+	now := time.Now().UTC()
+	instanceID := testAccAuraServer.AddInstance(aura.Instance{
+		Name:          "testprovidersnapshotsrecent",
+		TenantID:      testAccTenantID,
+		CloudProvider: "aws",
+		Region:        "us-east-1",
+		Type:          "enterprise-db",
+		Memory:        "2GB",
+	})
+	t.Cleanup(func() {
+		testAccAuraServer.RemoveInstances("testprovidersnapshotsrecent")
+	})
+	completed := testAccAuraServer.AddSnapshot(aura.Snapshot{
+		InstanceID: instanceID,
+		Profile:    "AdHoc",
+		Timestamp:  now.Add(-time.Minute).Format(time.RFC3339),
+	})
+	inProgress := testAccAuraServer.AddSnapshot(aura.Snapshot{
+		InstanceID: instanceID,
+		Profile:    "AdHoc",
+		Status:     "InProgress",
+		Timestamp:  now.Format(time.RFC3339),
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + fmt.Sprintf(`
+	data "pgrneo4jaura_aurasnapshots" "latest" {
+		instance_id = "%[1]s"
+		from = "%[2]s"
+		to = "%[3]s"
+		most_recent = true
+	}
+
+	data "pgrneo4jaura_aurasnapshots" "latest_in_progress" {
+		instance_id = "%[1]s"
+		from = "%[2]s"
+		to = "%[3]s"
+		status = "InProgress"
+		most_recent = true
+	}`, instanceID, now.AddDate(0, 0, -1).Format(time.DateOnly), now.Format(time.DateOnly)),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.pgrneo4jaura_aurasnapshots.latest",
+						tfjsonpath.New("ids"),
+						knownvalue.ListExact([]knownvalue.Check{knownvalue.StringExact(completed)}),
+					),
+					statecheck.ExpectKnownValue(
+						"data.pgrneo4jaura_aurasnapshots.latest_in_progress",
+						tfjsonpath.New("ids"),
+						knownvalue.ListExact([]knownvalue.Check{knownvalue.StringExact(inProgress)}),
+					),
+				},
+			},
+		},
+	})
+}
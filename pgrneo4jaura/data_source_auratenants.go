@@ -0,0 +1,177 @@
+package pgrneo4jaura
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"terraform-provider-pgrneo4jaura/aura"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource                   = &auraTenantsDataSource{}
+	_ datasource.DataSourceWithConfigure      = &auraTenantsDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &auraTenantsDataSource{}
+)
+
+var auraTenantAttrTypes = map[string]attr.Type{
+	"id":   types.StringType,
+	"name": types.StringType,
+}
+
+func NewAuraTenantsDataSource() datasource.DataSource {
+	return &auraTenantsDataSource{}
+}
+
+type auraTenantsDataSource struct {
+	client *aura.Client
+}
+
+type auraTenantsDataSourceModel struct {
+	Name      types.String `tfsdk:"name"`
+	NameRegex types.String `tfsdk:"name_regex"`
+	IDs       types.List   `tfsdk:"ids"`
+	Tenants   types.List   `tfsdk:"tenants"`
+}
+
+func (r *auraTenantsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_auratenants"
+}
+
+func (r *auraTenantsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Data lookup for the Neo4j Aura tenants (projects) the provider credentials can access",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Description: "Only return tenants with exactly this name.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("name_regex")),
+				},
+			},
+			"name_regex": schema.StringAttribute{
+				Description: "Only return tenants whose name matches this regular expression.",
+				Optional:    true,
+			},
+			//computed
+			"ids": schema.ListAttribute{
+				Description: "Ids of the matching tenants.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"tenants": schema.ListNestedAttribute{
+				Description: "The matching tenants, ordered by name.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Neo4j Aura tenant identifier.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "The tenant name.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *auraTenantsDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var config auraTenantsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if config.NameRegex.IsNull() || config.NameRegex.IsUnknown() {
+		return
+	}
+	if _, err := regexp.Compile(config.NameRegex.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name_regex"),
+			"Invalid name_regex",
+			"Could not compile name_regex. Received error: "+err.Error(),
+		)
+	}
+}
+
+func (r *auraTenantsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(providerData).client
+}
+
+func (r *auraTenantsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state auraTenantsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if !state.NameRegex.IsNull() {
+		nameRegex = regexp.MustCompile(state.NameRegex.ValueString()) // checked in ValidateConfig
+	}
+
+	tenants, err := r.client.GetTenants(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Get Neo4j Aura tenants.",
+			err.Error(),
+		)
+		return
+	}
+	sort.Slice(tenants, func(i, j int) bool {
+		if tenants[i].Name == tenants[j].Name {
+			return tenants[i].ID < tenants[j].ID
+		}
+		return tenants[i].Name < tenants[j].Name
+	})
+
+	ids := []attr.Value{}
+	objects := []attr.Value{}
+	for _, tenant := range tenants {
+		if !matchesFilter(state.Name, tenant.Name) {
+			continue
+		}
+		if nameRegex != nil && !nameRegex.MatchString(tenant.Name) {
+			continue
+		}
+		object, diags := types.ObjectValue(auraTenantAttrTypes, map[string]attr.Value{
+			"id":   types.StringValue(tenant.ID),
+			"name": types.StringValue(tenant.Name),
+		})
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		ids = append(ids, types.StringValue(tenant.ID))
+		objects = append(objects, object)
+	}
+
+	state.IDs, diags = types.ListValue(types.StringType, ids)
+	resp.Diagnostics.Append(diags...)
+	state.Tenants, diags = types.ListValue(types.ObjectType{AttrTypes: auraTenantAttrTypes}, objects)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,46 @@
+package pgrneo4jaura
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccPGRNeo4jAuraTenants(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+data "pgrneo4jaura_auratenants" "all" {
+}
+
+data "pgrneo4jaura_auratenants" "by_name" {
+	name = data.pgrneo4jaura_auratenants.all.tenants[0].name
+}
+
+data "pgrneo4jaura_auratenants" "none" {
+	name_regex = "^no tenant has this name$"
+}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					ExpectNotEmpty(
+						"data.pgrneo4jaura_auratenants.all",
+						tfjsonpath.New("tenants").AtSliceIndex(0).AtMapKey("id"),
+					),
+					ExpectNotEmpty(
+						"data.pgrneo4jaura_auratenants.by_name",
+						tfjsonpath.New("ids").AtSliceIndex(0),
+					),
+					statecheck.ExpectKnownValue(
+						"data.pgrneo4jaura_auratenants.none",
+						tfjsonpath.New("tenants"),
+						knownvalue.ListSizeExact(0),
+					),
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,57 @@
+package pgrneo4jaura
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ ephemeral.EphemeralResource = &auraPasswordEphemeralResource{}
+
+func NewAuraPasswordEphemeralResource() ephemeral.EphemeralResource {
+	return &auraPasswordEphemeralResource{}
+}
+
+// auraPasswordEphemeralResource generates the initial neo4j user password of
+// an instance outside of state. the password Aura generates on creation can
+// not be read back, so it is replaced by this one through n4jpwd_wo.
+type auraPasswordEphemeralResource struct{}
+
+type auraPasswordEphemeralResourceModel struct {
+	Password types.String `tfsdk:"password"`
+}
+
+func (r *auraPasswordEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_aurapassword"
+}
+
+func (r *auraPasswordEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Generates a neo4j user password that is never stored in plan or state, to pass to n4jpwd_wo of an instance and to the write-only argument of a secrets backend. Needs Terraform 1.10 or later. A new password is generated on every run, write-only arguments only apply it when their version changes.",
+		Attributes: map[string]schema.Attribute{
+			"password": schema.StringAttribute{
+				Description: "The generated password, 43 url safe characters.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
+
+func (r *auraPasswordEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	password, err := randomPassword()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Generating Neo4j Aura password",
+			"Could not generate a neo4j user password. Received error: "+err.Error(),
+		)
+		return
+	}
+
+	diags := resp.Result.Set(ctx, auraPasswordEphemeralResourceModel{
+		Password: types.StringValue(password),
+	})
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,59 @@
+package pgrneo4jaura
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccPGRNeo4jAuraPasswordEphemeralResource(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + fmt.Sprintf(`
+				ephemeral "pgrneo4jaura_aurapassword" "initial" {}
+
+				resource "pgrneo4jaura_aurainstance" "ephemeral" {
+					tenant_id = "%s"
+					name = "testproviderephemeralpassword"
+					type = "enterprise-db"
+					version = "5"
+					cloud_provider = "aws"
+					region = "us-east-1"
+					memory = "2GB"
+					n4jpwd_wo = ephemeral.pgrneo4jaura_aurapassword.initial.password
+					n4jpwd_wo_version = 1
+				}`, testAccTenantID),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"pgrneo4jaura_aurainstance.ephemeral",
+						tfjsonpath.New("n4jpwd"),
+						knownvalue.Null(),
+					),
+				},
+				Check: func(s *terraform.State) error {
+					if testAccAuraServer == nil {
+						return nil
+					}
+					id := s.RootModule().Resources["pgrneo4jaura_aurainstance.ephemeral"].Primary.ID
+					if password := testAccAuraServer.Password(id); len(password) != 43 {
+						return fmt.Errorf("expected the generated password to be set on instance %s, got %d characters", id, len(password))
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
@@ -2,31 +2,53 @@ package pgrneo4jaura
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"strconv"
+	"terraform-provider-pgrneo4jaura/aura"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-var _ provider.Provider = &pgrneo4jaura_provider{}
+var (
+	_ provider.Provider                       = &pgrneo4jaura_provider{}
+	_ provider.ProviderWithEphemeralResources = &pgrneo4jaura_provider{}
+)
 
 func New() provider.Provider {
-	return &pgrneo4jaura_provider{}
+	return &pgrneo4jaura_provider{
+		clientConfig: aura.Config{
+			PollInterval: aura.DefaultPollInterval,
+			SettleDelay:  aura.DefaultSettleDelay,
+		},
+	}
 }
 
-type pgrneo4jaura_provider struct{}
+type pgrneo4jaura_provider struct {
+	// clientConfig holds the client settings that are not exposed in the
+	// provider schema. tests shorten the polling intervals through it.
+	clientConfig aura.Config
+}
 
 type pgrneo4jauraProviderModel struct {
 	ClientID     types.String `tfsdk:"client_id"`
 	ClientSecret types.String `tfsdk:"client_secret"`
+	APIURL       types.String `tfsdk:"api_url"`
+	MaxAttempts  types.Int64  `tfsdk:"max_attempts"`
+	SettleDelay  types.String `tfsdk:"settle_delay"`
 }
 
 type providerData struct {
-	access_token string
+	client *aura.Client
 }
 
 func (p *pgrneo4jaura_provider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -46,6 +68,21 @@ func (p *pgrneo4jaura_provider) Schema(_ context.Context, _ provider.SchemaReque
 				Sensitive:   true,
 				Description: "Progressive Neo4j Aura API client secret.",
 			},
+			"api_url": schema.StringAttribute{
+				Optional:    true,
+				Description: "Neo4j Aura API base url. Defaults to " + aura.DefaultAPIURL + ". May also be set with the PGRNEO4J_APIURL environment variable.",
+			},
+			"max_attempts": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum number of attempts for Neo4j Aura API requests that fail with a timeout, rate limit or server error. Defaults to " + strconv.Itoa(aura.DefaultMaxAttempts) + ".",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"settle_delay": schema.StringAttribute{
+				Optional:    true,
+				Description: "Extra time to wait after an instance or cmk operation reports completion without its status having been seen to change, as a duration string such as \"60s\". Defaults to " + formatDuration(aura.DefaultSettleDelay) + ", \"0s\" disables it.",
+			},
 		},
 	}
 }
@@ -77,12 +114,22 @@ func (p *pgrneo4jaura_provider) Configure(ctx context.Context, req provider.Conf
 		)
 	}
 
+	if config.APIURL.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("api_url"),
+			"Unknown Neo4j Aura api url",
+			"The provider cannot connect as there is an unkonwn configuration value for the api url. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the PGRNEO4J_APIURL environment variable.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	client_id := os.Getenv("PGRNEO4J_CLIENTID")
 	client_secret := os.Getenv("PGRNEO4J_CLIENTSECERET")
+	api_url := os.Getenv("PGRNEO4J_APIURL")
 
 	if !config.ClientID.IsNull() {
 		client_id = config.ClientID.ValueString()
@@ -92,6 +139,10 @@ func (p *pgrneo4jaura_provider) Configure(ctx context.Context, req provider.Conf
 		client_secret = config.ClientSecret.ValueString()
 	}
 
+	if !config.APIURL.IsNull() {
+		api_url = config.APIURL.ValueString()
+	}
+
 	if client_id == "" {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("client_id"),
@@ -112,7 +163,29 @@ func (p *pgrneo4jaura_provider) Configure(ctx context.Context, req provider.Conf
 		return
 	}
 
-	access_token, err := getNeo4jAuraAuthToken(client_id, client_secret)
+	clientConfig := p.clientConfig
+	clientConfig.APIURL = api_url
+	clientConfig.ClientID = client_id
+	clientConfig.ClientSecret = client_secret
+	if !config.MaxAttempts.IsNull() && !config.MaxAttempts.IsUnknown() {
+		clientConfig.MaxAttempts = int(config.MaxAttempts.ValueInt64())
+	}
+	if !config.SettleDelay.IsNull() && !config.SettleDelay.IsUnknown() {
+		settleDelay, err := time.ParseDuration(config.SettleDelay.ValueString())
+		if err == nil && settleDelay < 0 {
+			err = fmt.Errorf("settle delay %s is negative", settleDelay)
+		}
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("settle_delay"),
+				"Invalid Neo4j Aura settle delay",
+				"Could not parse settle delay. Received error: "+err.Error(),
+			)
+			return
+		}
+		clientConfig.SettleDelay = settleDelay
+	}
+	client, err := aura.NewClient(ctx, clientConfig)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to authenticate to Neo4j Aura",
@@ -123,7 +196,7 @@ func (p *pgrneo4jaura_provider) Configure(ctx context.Context, req provider.Conf
 		return
 	}
 
-	data.access_token = access_token
+	data.client = client
 	resp.ResourceData = data
 	resp.DataSourceData = data
 }
@@ -132,6 +205,12 @@ func (p *pgrneo4jaura_provider) DataSources(_ context.Context) []func() datasour
 	return []func() datasource.DataSource{
 		NewAuraSizingEstimateDataSource,
 		NewAuraProjectsDataSource,
+		NewAuraInstanceDataSource,
+		NewAuraInstancesDataSource,
+		NewAuraTenantsDataSource,
+		NewAuraCMKDataSource,
+		NewAuraCMKsDataSource,
+		NewAuraSnapshotsDataSource,
 	}
 }
 
@@ -139,5 +218,14 @@ func (p *pgrneo4jaura_provider) Resources(_ context.Context) []func() resource.R
 	return []func() resource.Resource{
 		NewAuraInstanceResource,
 		NewAuraCMKResource,
+		NewAuraSnapshotResource,
+		NewAuraRestoreResource,
+		NewAuraOverwriteResource,
+	}
+}
+
+func (p *pgrneo4jaura_provider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewAuraPasswordEphemeralResource,
 	}
 }
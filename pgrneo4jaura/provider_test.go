@@ -1,6 +1,13 @@
 package pgrneo4jaura
 
 import (
+	"context"
+	"os"
+	"terraform-provider-pgrneo4jaura/aura"
+	"terraform-provider-pgrneo4jaura/aura/auratest"
+	"testing"
+	"time"
+
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 )
@@ -12,6 +19,59 @@ provider "pgrneo4jaura" {
 `
 )
 
-var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
-	"pgrneo4jaura": providerserver.NewProtocol6WithError(New()),
+var (
+	testAccProtoV6ProviderFactories map[string]func() (tfprotov6.ProviderServer, error)
+
+	// testAccTenantID is the tenant the acceptance tests create objects in.
+	testAccTenantID string
+
+	// testAccAuraServer is the fake Aura API the acceptance tests run against.
+	// nil when PGRNEO4J_CLIENTID is set and the tests run against the real api.
+	testAccAuraServer *auratest.Server
+)
+
+// without PGRNEO4J_CLIENTID the acceptance tests run offline against an
+// in-process fake of the Aura API. set PGRNEO4J_CLIENTID,
+// PGRNEO4J_CLIENTSECERET and PGRNEO4J_TENANTID to run them against Aura.
+func TestMain(m *testing.M) {
+	p := New()
+	testAccTenantID = os.Getenv("PGRNEO4J_TENANTID")
+	if os.Getenv("PGRNEO4J_CLIENTID") == "" {
+		testAccAuraServer = auratest.NewServer()
+		os.Setenv("PGRNEO4J_APIURL", testAccAuraServer.URL)
+		os.Setenv("PGRNEO4J_CLIENTID", auratest.ClientID)
+		os.Setenv("PGRNEO4J_CLIENTSECERET", auratest.ClientSecret)
+		testAccTenantID = auratest.TenantID
+		p = &pgrneo4jaura_provider{
+			clientConfig: aura.Config{
+				PollInterval: 10 * time.Millisecond,
+				RetryDelay:   10 * time.Millisecond,
+				HTTPClient:   testAccAuraServer.HTTPClient(),
+			},
+		}
+	}
+	testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+		"pgrneo4jaura": providerserver.NewProtocol6WithError(p),
+	}
+
+	code := m.Run()
+	if testAccAuraServer != nil {
+		testAccAuraServer.Close()
+	}
+	os.Exit(code)
+}
+
+// testAccClient talks to the same api as the provider under test, for
+// changing objects behind terraform's back.
+func testAccClient(t *testing.T) *aura.Client {
+	t.Helper()
+	client, err := aura.NewClient(context.Background(), aura.Config{
+		APIURL:       os.Getenv("PGRNEO4J_APIURL"),
+		ClientID:     os.Getenv("PGRNEO4J_CLIENTID"),
+		ClientSecret: os.Getenv("PGRNEO4J_CLIENTSECERET"),
+	})
+	if err != nil {
+		t.Fatalf("unable to create aura client: %v", err)
+	}
+	return client
 }
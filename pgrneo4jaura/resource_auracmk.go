@@ -5,10 +5,14 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"terraform-provider-pgrneo4jaura/aura"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
@@ -21,25 +25,43 @@ var (
 	_ resource.Resource                = &neo4jAuraCMKResource{}
 	_ resource.ResourceWithConfigure   = &neo4jAuraCMKResource{}
 	_ resource.ResourceWithImportState = &neo4jAuraCMKResource{}
+	_ resource.ResourceWithIdentity    = &neo4jAuraCMKResource{}
 )
 
+// default operation timeouts, a cmk is replaced rather than updated.
+var cmkTimeouts = map[string]time.Duration{
+	"create": 30 * time.Minute,
+	"delete": 30 * time.Minute,
+}
+
+// cmk ids are uuids, anything else given to import is a name.
+var cmkIDPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
 func NewAuraCMKResource() resource.Resource {
 	return &neo4jAuraCMKResource{}
 }
 
 type neo4jAuraCMKResource struct {
-	access_token string
+	client *aura.Client
 }
 
 type neo4jAuraCMKResourceModel struct {
-	ID            types.String `tfsdk:"id"`
-	TenantID      types.String `tfsdk:"tenant_id"`
-	Region        types.String `tfsdk:"region"`
-	InstanceType  types.String `tfsdk:"instance_type"`
-	CloudProvider types.String `tfsdk:"cloud_provider"`
-	Name          types.String `tfsdk:"name"`
-	KeyID         types.String `tfsdk:"key_id"`
-	Created       types.String `tfsdk:"created"`
+	ID            types.String   `tfsdk:"id"`
+	TenantID      types.String   `tfsdk:"tenant_id"`
+	Region        types.String   `tfsdk:"region"`
+	InstanceType  types.String   `tfsdk:"instance_type"`
+	CloudProvider types.String   `tfsdk:"cloud_provider"`
+	Name          types.String   `tfsdk:"name"`
+	KeyID         types.String   `tfsdk:"key_id"`
+	Created       types.String   `tfsdk:"created"`
+	Status        types.String   `tfsdk:"status"`
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
+}
+
+// a cmk is identified by its id, the tenant is checked when given.
+type neo4jAuraCMKResourceIdentityModel struct {
+	ID       types.String `tfsdk:"id"`
+	TenantID types.String `tfsdk:"tenant_id"`
 }
 
 // tenant_id,storage,cloud_provider,type,version,name,region,memory,
@@ -47,7 +69,7 @@ func (r *neo4jAuraCMKResource) Metadata(_ context.Context, req resource.Metadata
 	resp.TypeName = req.ProviderTypeName + "_auracmk"
 }
 
-func (r *neo4jAuraCMKResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *neo4jAuraCMKResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages a Neo4j Aura Customer Managed Key (CMK)",
 		Attributes: map[string]schema.Attribute{
@@ -124,6 +146,31 @@ func (r *neo4jAuraCMKResource) Schema(_ context.Context, _ resource.SchemaReques
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"status": schema.StringAttribute{
+				Description: "Neo4j Aura CMK status, only a ready cmk can be used by instances.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx, cmkTimeouts),
+		},
+	}
+}
+
+func (r *neo4jAuraCMKResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.StringAttribute{
+				Description:       "Neo4j Aura CMK id.",
+				RequiredForImport: true,
+			},
+			"tenant_id": identityschema.StringAttribute{
+				Description:       "Neo4j Aura tenant identifier.",
+				OptionalForImport: true,
+			},
 		},
 	}
 }
@@ -133,7 +180,7 @@ func (r *neo4jAuraCMKResource) Configure(_ context.Context, req resource.Configu
 		return
 	}
 
-	r.access_token = req.ProviderData.(providerData).access_token
+	r.client = req.ProviderData.(providerData).client
 }
 
 func (r *neo4jAuraCMKResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -144,6 +191,13 @@ func (r *neo4jAuraCMKResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
+	ctx, cancel, diags := withOperationTimeout(ctx, plan.Timeouts, "create", cmkTimeouts["create"])
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	tenantID := plan.TenantID.ValueString()
 	region := plan.Region.ValueString()
 	instanceType := plan.InstanceType.ValueString()
@@ -152,7 +206,14 @@ func (r *neo4jAuraCMKResource) Create(ctx context.Context, req resource.CreateRe
 	keyId := plan.KeyID.ValueString()
 
 	tflog.Info(ctx, fmt.Sprintf("creating neo4j cmk %s", name))
-	cmk, err := neo4jCreateCMK(ctx, r.access_token, tenantID, name, region, instanceType, cloudProvider, keyId)
+	cmk, err := r.client.CreateCMK(ctx, aura.CreateCMKRequest{
+		Name:          name,
+		TenantID:      tenantID,
+		CloudProvider: cloudProvider,
+		Region:        region,
+		InstanceType:  instanceType,
+		KeyID:         keyId,
+	})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating Neo4j Aura CMK",
@@ -162,16 +223,20 @@ func (r *neo4jAuraCMKResource) Create(ctx context.Context, req resource.CreateRe
 	}
 
 	tflog.Info(ctx, "created neo4j cmk")
-	tflog.Debug(ctx, "cmk details: %v", cmk)
+	tflog.Debug(ctx, fmt.Sprintf("cmk details: %+v", cmk))
 
-	plan.ID = types.StringValue(cmk["data"].(map[string]interface{})["id"].(string))
-	plan.Created = types.StringValue(cmk["data"].(map[string]interface{})["created"].(string))
+	plan.ID = types.StringValue(cmk.ID)
+	plan.Created = types.StringValue(cmk.Created)
+	plan.Status = types.StringValue(cmk.Status)
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	diags = resp.Identity.Set(ctx, neo4jAuraCMKResourceIdentityModel{ID: plan.ID, TenantID: plan.TenantID})
+	resp.Diagnostics.Append(diags...)
 }
 
 func (r *neo4jAuraCMKResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -183,9 +248,17 @@ func (r *neo4jAuraCMKResource) Read(ctx context.Context, req resource.ReadReques
 	}
 
 	id := state.ID.ValueString()
-	cmk, statusCode, err := neo4jGetCMK(r.access_token, id)
+	cmk, err := r.client.GetCMK(ctx, id)
 	tflog.Info(ctx, fmt.Sprintf("reading neo4j cmk %s", id))
-	tflog.Debug(ctx, fmt.Sprintf("cmk details (http: %d): %v", statusCode, cmk))
+	tflog.Debug(ctx, fmt.Sprintf("cmk details: %+v", cmk))
+	if aura.IsNotFound(err) {
+		resp.Diagnostics.AddWarning(
+			"Neo4j Aura CMK not found",
+			"Neo4j Aura CMK "+id+" no longer exists and has been removed from state. It was likely deleted outside of Terraform, apply will create it again.",
+		)
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Neo4j Aura CMK",
@@ -194,14 +267,32 @@ func (r *neo4jAuraCMKResource) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 
-	state.ID = types.StringValue(cmk["data"].(map[string]interface{})["id"].(string))
-	state.Created = types.StringValue(cmk["data"].(map[string]interface{})["created"].(string))
+	state.ID = types.StringValue(cmk.ID)
+	state.Name = types.StringValue(cmk.Name)
+	state.TenantID = types.StringValue(cmk.TenantID)
+	state.CloudProvider = types.StringValue(cmk.CloudProvider)
+	state.Region = types.StringValue(cmk.Region)
+	state.InstanceType = types.StringValue(cmk.InstanceType)
+	state.KeyID = types.StringValue(cmk.KeyID)
+	state.Created = types.StringValue(cmk.Created)
+	state.Status = types.StringValue(cmk.Status)
+	if cmk.Status != "ready" {
+		// e.g. the grant on the cloud kms key was revoked
+		resp.Diagnostics.AddWarning(
+			"Neo4j Aura CMK not ready",
+			"Neo4j Aura CMK "+id+" is "+cmk.Status+". Instances can not be created with it, check the key and its grants in the cloud provider.",
+		)
+	}
 
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	// state written by earlier provider versions has no identity yet
+	diags = resp.Identity.Set(ctx, neo4jAuraCMKResourceIdentityModel{ID: state.ID, TenantID: state.TenantID})
+	resp.Diagnostics.Append(diags...)
 }
 
 func (r *neo4jAuraCMKResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -219,13 +310,17 @@ func (r *neo4jAuraCMKResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
-	tflog.Info(ctx, fmt.Sprintf("neo4j cmk should require replace for any updates"))
+	tflog.Info(ctx, "neo4j cmk should require replace for any updates")
+	state.Timeouts = plan.Timeouts
 
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	diags = resp.Identity.Set(ctx, neo4jAuraCMKResourceIdentityModel{ID: state.ID, TenantID: state.TenantID})
+	resp.Diagnostics.Append(diags...)
 }
 
 func (r *neo4jAuraCMKResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -236,9 +331,20 @@ func (r *neo4jAuraCMKResource) Delete(ctx context.Context, req resource.DeleteRe
 		return
 	}
 
+	ctx, cancel, diags := withOperationTimeout(ctx, state.Timeouts, "delete", cmkTimeouts["delete"])
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	id := state.ID.ValueString()
 	tflog.Info(ctx, fmt.Sprintf("deleting neo4j cmk with id %s", id))
-	err := neo4jDeleteCMK(ctx, r.access_token, id)
+	err := r.client.DeleteCMK(ctx, id)
+	if aura.IsNotFound(err) {
+		tflog.Info(ctx, fmt.Sprintf("neo4j cmk %s was already deleted", id))
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting Neo4j Aura CMK",
@@ -249,49 +355,90 @@ func (r *neo4jAuraCMKResource) Delete(ctx context.Context, req resource.DeleteRe
 	return
 }
 
-// terraform import pgrneo4jaura_auracmk.mycmk <CMK NAME>
+// terraform import pgrneo4jaura_auracmk.mycmk <CMK ID>
+// terraform import pgrneo4jaura_auracmk.mycmk <TENANT ID>/<CMK NAME>
+// import { to = pgrneo4jaura_auracmk.mycmk, identity = { id = <CMK ID>, tenant_id = <TENANT ID> } }
+//
+// a bare name is still accepted for existing scripts as long as it is unique
+// across tenants.
 func (r *neo4jAuraCMKResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	importParts := strings.Split(req.ID, ",")
-	if len(importParts) != 1 {
-		resp.Diagnostics.AddError(
-			"Error Importing Neo4j Aura CMK",
-			"Could not import Neo4j Aura CMK.\nPlease ensure you run \"terraform import resource_type.resource_name <cmk_name>",
-		)
-		return
-	}
-	name := importParts[0]
+	id := ""
+	tenantID := ""
+	if req.ID == "" { // import block by identity
+		var identity neo4jAuraCMKResourceIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		id = identity.ID.ValueString()
+		tenantID = identity.TenantID.ValueString()
+	} else {
+		name := req.ID
+		if before, after, scoped := strings.Cut(req.ID, "/"); scoped {
+			tenantID, name = before, after
+			if tenantID == "" || name == "" {
+				resp.Diagnostics.AddError(
+					"Error Importing Neo4j Aura CMK",
+					"Could not import Neo4j Aura CMK.\nPlease ensure you run \"terraform import resource_type.resource_name <cmk_id>\" or \"terraform import resource_type.resource_name <tenant_id>/<cmk_name>\"",
+				)
+				return
+			}
+		} else if cmkIDPattern.MatchString(req.ID) {
+			// a name can look like an id, fall back to the names when the
+			// api does not know it as one
+			_, err := r.client.GetCMK(ctx, req.ID)
+			if err == nil {
+				id = req.ID
+			} else if !aura.IsNotFound(err) && !aura.IsBadRequest(err) {
+				resp.Diagnostics.AddError(
+					"Error Importing Neo4j Aura CMK",
+					"Could not import Neo4j Aura CMK "+req.ID+". Received error: "+err.Error(),
+				)
+				return
+			}
+		}
 
-	cmks, err := neo4jGetCMKs(r.access_token)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Importing Neo4j Aura CMK",
-			"Could not retrieve list of Neo4j Aura CMKs. Received error: "+err.Error(),
-		)
-		return
-	}
+		if id == "" {
+			cmks, err := r.client.GetCMKs(ctx)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error Importing Neo4j Aura CMK",
+					"Could not retrieve list of Neo4j Aura CMKs. Received error: "+err.Error(),
+				)
+				return
+			}
 
-	id := ""
-	if data, ok := cmks["data"].([]interface{}); ok {
-		for _, item := range data {
-			if itemMap, ok := item.(map[string]interface{}); ok {
-				if itemMap["name"] == name {
-					id = itemMap["id"].(string)
+			var matches []string
+			for _, item := range cmks {
+				if item.Name == name && (tenantID == "" || item.TenantID == tenantID) {
+					matches = append(matches, item.TenantID+"/"+item.ID)
 				}
-			} else {
-				fmt.Println("Invalid item type in data")
 			}
+			if len(matches) == 0 {
+				where := "any tenant"
+				if tenantID != "" {
+					where = "tenant " + tenantID
+				}
+				resp.Diagnostics.AddError(
+					"Error Importing Neo4j Aura CMK",
+					fmt.Sprintf("No Neo4j Aura CMK with id or name %q exists in %s.", name, where),
+				)
+				return
+			}
+			if len(matches) > 1 {
+				resp.Diagnostics.AddError(
+					"Error Importing Neo4j Aura CMK",
+					fmt.Sprintf("Found %d Neo4j Aura CMKs named %q: %s. Import by <cmk_id> or <tenant_id>/<cmk_name> instead.", len(matches), name, strings.Join(matches, ", ")),
+				)
+				return
+			}
+			_, id, _ = strings.Cut(matches[0], "/")
 		}
-	} else {
-		resp.Diagnostics.AddError(
-			"Error Importing Neo4j Aura CMK",
-			"Could not retrieve list of Neo4j Aura CMKs. Received error: "+err.Error(),
-		)
-		return
 	}
 
 	tflog.Info(ctx, "importing neo4j cmk")
-	cmk, statusCode, err := neo4jGetCMK(r.access_token, id)
-	tflog.Debug(ctx, fmt.Sprintf("cmk details (http: %d): %v", statusCode, cmk))
+	cmk, err := r.client.GetCMK(ctx, id)
+	tflog.Debug(ctx, fmt.Sprintf("cmk details: %+v", cmk))
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -300,20 +447,30 @@ func (r *neo4jAuraCMKResource) ImportState(ctx context.Context, req resource.Imp
 		)
 		return
 	}
+	if tenantID != "" && tenantID != cmk.TenantID {
+		resp.Diagnostics.AddError(
+			"Error Importing Neo4j Aura CMK",
+			"Neo4j Aura CMK "+id+" belongs to tenant "+cmk.TenantID+", not "+tenantID+".",
+		)
+		return
+	}
 
-	cloud_provider := cmk["data"].(map[string]interface{})["cloud_provider"].(string)
-	created := cmk["data"].(map[string]interface{})["created"].(string)
-	instanceType := cmk["data"].(map[string]interface{})["instance_type"].(string)
-	keyId := cmk["data"].(map[string]interface{})["key_id"].(string)
-	region := cmk["data"].(map[string]interface{})["region"].(string)
-	tenant_id := cmk["data"].(map[string]interface{})["tenant_id"].(string)
+	cloud_provider := cmk.CloudProvider
+	created := cmk.Created
+	instanceType := cmk.InstanceType
+	keyId := cmk.KeyID
+	name := cmk.Name
+	region := cmk.Region
+	tenant_id := cmk.TenantID
 
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("created"), created)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("status"), cmk.Status)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cloud_provider"), cloud_provider)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("instance_type"), instanceType)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("key_id"), keyId)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("region"), region)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tenant_id"), tenant_id)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, neo4jAuraCMKResourceIdentityModel{ID: types.StringValue(id), TenantID: types.StringValue(tenant_id)})...)
 }
@@ -2,17 +2,21 @@ package pgrneo4jaura
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
 
 func TestAccPGRNeo4jCMK(t *testing.T) {
 	t.Parallel()
 
-	tenantID := "00000000-0000-0000-0000-000000000000"
+	tenantID := testAccTenantID
 	keyID := "arn:aws:kms:us-east-1:123456789012:key/mrk-00000000000000000000000000000000"
 
 	// quick test
@@ -30,8 +34,193 @@ func TestAccPGRNeo4jCMK(t *testing.T) {
 						"pgrneo4jaura_auracmk.cmk",
 						tfjsonpath.New("created"),
 					),
+					statecheck.ExpectKnownValue(
+						"pgrneo4jaura_auracmk.cmk",
+						tfjsonpath.New("status"),
+						knownvalue.StringExact("ready"),
+					),
+				},
+			},
+		},
+	})
+}
+
+func TestAccPGRNeo4jCMKDeletedOutsideTerraform(t *testing.T) {
+	if testAccAuraServer == nil {
+		t.Skip("deleting a cmk out of band needs the fake Aura API")
+	}
+	t.Parallel()
+
+	config := providerConfig + fmt.Sprintf(`
+	resource "pgrneo4jaura_auracmk" "drift" {
+		tenant_id = "%s"
+		cloud_provider = "aws"
+		instance_type = "enterprise-db"
+		name = "mycmkdrift"
+		region = "us-east-1"
+		key_id = "arn:aws:kms:us-east-1:123456789012:key/mrk-drift"
+	}`, testAccTenantID)
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			{
+				PreConfig: func() {
+					if testAccAuraServer.RemoveCMKs("mycmkdrift") != 1 {
+						t.Fatal("expected to remove cmk mycmkdrift")
+					}
+				},
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("pgrneo4jaura_auracmk.drift", plancheck.ResourceActionCreate),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAccPGRNeo4jCMKNotReady(t *testing.T) {
+	if testAccAuraServer == nil {
+		t.Skip("revoking a cmk out of band needs the fake Aura API")
+	}
+	t.Parallel()
+
+	cmkConfig := providerConfig + fmt.Sprintf(`
+	resource "pgrneo4jaura_auracmk" "revoked" {
+		tenant_id = "%s"
+		cloud_provider = "aws"
+		instance_type = "enterprise-db"
+		name = "mycmkrevoked"
+		region = "us-east-1"
+		key_id = "arn:aws:kms:us-east-1:123456789012:key/mrk-revoked"
+	}`, testAccTenantID)
+	instanceConfig := func(region string) string {
+		return fmt.Sprintf(`
+		resource "pgrneo4jaura_aurainstance" "encrypted" {
+			tenant_id = "%s"
+			name = "testproviderencrypted"
+			type = "enterprise-db"
+			version = "5"
+			cloud_provider = "aws"
+			region = "%s"
+			memory = "2GB"
+			customer_managed_key_id = pgrneo4jaura_auracmk.revoked.id
+		}`, testAccTenantID, region)
+	}
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: cmkConfig,
+			},
+			{
+				Config:      cmkConfig + instanceConfig("eu-west-1"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid Neo4j Aura CMK`),
+			},
+			{
+				PreConfig: func() {
+					if testAccAuraServer.SetCMKStatus("mycmkrevoked", "revoked") != 1 {
+						t.Fatal("expected to revoke cmk mycmkrevoked")
+					}
+				},
+				Config: cmkConfig,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"pgrneo4jaura_auracmk.revoked",
+						tfjsonpath.New("status"),
+						knownvalue.StringExact("revoked"),
+					),
+				},
+			},
+			{
+				Config:      cmkConfig + instanceConfig("us-east-1"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Neo4j Aura CMK not ready`),
+			},
+		},
+	})
+}
+
+func TestAccPGRNeo4jCMKImport(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + fmt.Sprintf(`
+				resource "pgrneo4jaura_auracmk" "imported" {
+					tenant_id = "%s"
+					cloud_provider = "aws"
+					instance_type = "enterprise-db"
+					name = "mycmkimported"
+					region = "us-east-1"
+					key_id = "arn:aws:kms:us-east-1:123456789012:key/mrk-imported"
+				}`, testAccTenantID),
+			},
+			{
+				// by id
+				ResourceName:      "pgrneo4jaura_auracmk.imported",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "pgrneo4jaura_auracmk.imported",
+				ImportState:       true,
+				ImportStateId:     testAccTenantID + "/mycmkimported",
+				ImportStateVerify: true,
+			},
+			{
+				// by name, without probing it as an id
+				ResourceName:      "pgrneo4jaura_auracmk.imported",
+				ImportState:       true,
+				ImportStateId:     "mycmkimported",
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:  "pgrneo4jaura_auracmk.imported",
+				ImportState:   true,
+				ImportStateId: testAccTenantID + "/mycmkmissing",
+				ExpectError:   regexp.MustCompile(`No Neo4j Aura CMK with id or name "mycmkmissing"`),
+			},
+		},
+	})
+}
+
+func TestAccPGRNeo4jCMKImportByIdentity(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + fmt.Sprintf(`
+				resource "pgrneo4jaura_auracmk" "identity" {
+					tenant_id = "%s"
+					cloud_provider = "aws"
+					instance_type = "enterprise-db"
+					name = "mycmkidentity"
+					region = "us-east-1"
+					key_id = "arn:aws:kms:us-east-1:123456789012:key/mrk-identity"
+				}`, testAccTenantID),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentityValueMatchesState("pgrneo4jaura_auracmk.identity", tfjsonpath.New("id")),
+					statecheck.ExpectIdentityValueMatchesState("pgrneo4jaura_auracmk.identity", tfjsonpath.New("tenant_id")),
 				},
 			},
+			{
+				ResourceName:    "pgrneo4jaura_auracmk.identity",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
 		},
 	})
 }
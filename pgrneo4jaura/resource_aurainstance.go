@@ -2,18 +2,29 @@ package pgrneo4jaura
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/base64"
 	"fmt"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"terraform-provider-pgrneo4jaura/aura"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -24,35 +35,57 @@ var (
 	_ resource.Resource                = &neo4jAuraResource{}
 	_ resource.ResourceWithConfigure   = &neo4jAuraResource{}
 	_ resource.ResourceWithImportState = &neo4jAuraResource{}
+	_ resource.ResourceWithModifyPlan  = &neo4jAuraResource{}
+	_ resource.ResourceWithIdentity    = &neo4jAuraResource{}
 )
 
+// default operation timeouts, resizing large enterprise instances can take well
+// over half an hour.
+var instanceTimeouts = map[string]time.Duration{
+	"create": 60 * time.Minute,
+	"update": 90 * time.Minute,
+	"delete": 30 * time.Minute,
+}
+
 func NewAuraInstanceResource() resource.Resource {
 	return &neo4jAuraResource{}
 }
 
 type neo4jAuraResource struct {
-	access_token string
+	client *aura.Client
 }
 
 type neo4jAuraResourceModel struct {
-	ID              types.String `tfsdk:"id"`
-	ConnectionURL   types.String `tfsdk:"connection_url"`
-	Version         types.String `tfsdk:"version"`
-	Region          types.String `tfsdk:"region"`
-	Memory          types.String `tfsdk:"memory"`
-	InstanceType    types.String `tfsdk:"type"`
-	TenantID        types.String `tfsdk:"tenant_id"`
-	CloudProvider   types.String `tfsdk:"cloud_provider"`
-	Name            types.String `tfsdk:"name"`
-	Storage         types.String `tfsdk:"storage"`
-	Paused          types.Bool   `tfsdk:"paused"`
-	NeoUser         types.Bool   `tfsdk:"n4jusr"`
-	NeoPwd          types.String `tfsdk:"n4jpwd"`
-	CMK             types.String `tfsdk:"customer_managed_key_id"`
-	VectorOptimized types.Bool   `tfsdk:"vector_optimized"`
-	GDSPlugin       types.Bool   `tfsdk:"graph_analytics_plugin"`
-	MetricsURL      types.String `tfsdk:"metrics_integration_url"`
-	Secondaries     types.Int64  `tfsdk:"secondary_count"`
+	ID              types.String   `tfsdk:"id"`
+	ConnectionURL   types.String   `tfsdk:"connection_url"`
+	Version         types.String   `tfsdk:"version"`
+	Region          types.String   `tfsdk:"region"`
+	Memory          types.String   `tfsdk:"memory"`
+	InstanceType    types.String   `tfsdk:"type"`
+	TenantID        types.String   `tfsdk:"tenant_id"`
+	CloudProvider   types.String   `tfsdk:"cloud_provider"`
+	Name            types.String   `tfsdk:"name"`
+	Storage         types.String   `tfsdk:"storage"`
+	Paused          types.Bool     `tfsdk:"paused"`
+	NeoUser         types.Bool     `tfsdk:"n4jusr"`
+	NeoPwd          types.String   `tfsdk:"n4jpwd"`
+	PwdRotation     types.String   `tfsdk:"password_rotation_trigger"`
+	PwdWO           types.String   `tfsdk:"n4jpwd_wo"`
+	PwdWOVersion    types.Int64    `tfsdk:"n4jpwd_wo_version"`
+	CMK             types.String   `tfsdk:"customer_managed_key_id"`
+	VectorOptimized types.Bool     `tfsdk:"vector_optimized"`
+	GDSPlugin       types.Bool     `tfsdk:"graph_analytics_plugin"`
+	MetricsURL      types.String   `tfsdk:"metrics_integration_url"`
+	Secondaries     types.Int64    `tfsdk:"secondary_count"`
+	CDC             types.String   `tfsdk:"cdc_enrichment_mode"`
+	SourceInstance  types.String   `tfsdk:"source_instance_id"`
+	SourceSnapshot  types.String   `tfsdk:"source_snapshot_id"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
+}
+
+// an instance is identified by its id alone, instance ids are unique across tenants.
+type neo4jAuraResourceIdentityModel struct {
+	ID types.String `tfsdk:"id"`
 }
 
 // tenant_id,storage,cloud_provider,type,version,name,region,memory,
@@ -60,7 +93,7 @@ func (r *neo4jAuraResource) Metadata(_ context.Context, req resource.MetadataReq
 	resp.TypeName = req.ProviderTypeName + "_aurainstance"
 }
 
-func (r *neo4jAuraResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *neo4jAuraResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages a Neo4j Aura Instance",
 		Attributes: map[string]schema.Attribute{
@@ -124,17 +157,14 @@ func (r *neo4jAuraResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				},
 			},
 			"region": schema.StringAttribute{
-				Description: "Neo4j Aura instance region.",
+				Description: "Neo4j Aura instance region. Checked at plan time against the regions the tenant offers for the cloud provider, type and version.",
 				Required:    true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
-				Validators: []validator.String{
-					stringvalidator.OneOf([]string{"us-east-1", "us-west-2", "eu-west-1", "sa-east-1", "ap-southeast-1", "australia-southeast1", "us-east1", "us-central1", "us-west1", "europe-west2", "europe-west1", "europe-west3", "asia-east1", "asia-east2", "eastus", "westus3", "francecentral", "brazilsouth", "koreacentral"}...),
-				},
 			},
 			"memory": schema.StringAttribute{
-				Description: "Neo4j Aura instance memory size.",
+				Description: "Neo4j Aura instance memory size. Checked at plan time against the sizes the tenant offers in the region.",
 				Required:    true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
@@ -161,13 +191,39 @@ func (r *neo4jAuraResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				Computed:    true,
 				Default:     int64default.StaticInt64(0),
 			},
+			"cdc_enrichment_mode": schema.StringAttribute{
+				Description: "Neo4j Aura instance change data capture enrichment mode, one of OFF, DIFF or FULL.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("OFF"),
+				Validators: []validator.String{
+					stringvalidator.OneOf([]string{"OFF", "DIFF", "FULL"}...),
+				},
+			},
 			"customer_managed_key_id": schema.StringAttribute{
-				Description: "Neo4j Aura Customer Managed Key (CMK).",
+				Description: "Neo4j Aura Customer Managed Key (CMK). Checked at plan time to exist, be ready and match the cloud provider, region and type of the instance.",
 				Optional:    true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"source_instance_id": schema.StringAttribute{
+				Description: "Create the instance as a clone of this Neo4j Aura instance. Only used on creation, changing it replaces the instance unless it was unset, e.g. after an import, or is removed.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(requiresReplaceIfSet, "Replaces the instance when changed from one value to another.", "Replaces the instance when changed from one value to another."),
+				},
+			},
+			"source_snapshot_id": schema.StringAttribute{
+				Description: "Clone the instance from this snapshot of source_instance_id rather than from its current data. Only used on creation, changing it replaces the instance unless it was unset, e.g. after an import, or is removed.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(requiresReplaceIfSet, "Replaces the instance when changed from one value to another.", "Replaces the instance when changed from one value to another."),
+				},
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("source_instance_id")),
+				},
+			},
 			"vector_optimized": schema.BoolAttribute{
 				Description: "An optional vector optimization configuration to be set during instance creation.",
 				Optional:    true,
@@ -187,12 +243,35 @@ func (r *neo4jAuraResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				},
 			},
 			"n4jusr": schema.BoolAttribute{
-				Description: "Controls retrieval of default neo4j user password upon creation.",
+				Description: "Controls retrieval of default neo4j user password upon creation. Unset after an import by id, in which case the configured value is adopted.",
 				Optional:    true,
 				Computed:    true,
 				Default:     booldefault.StaticBool(true),
 				PlanModifiers: []planmodifier.Bool{
-					boolplanmodifier.RequiresReplace(),
+					// unset after an import by id, the configured value is adopted
+					boolplanmodifier.RequiresReplaceIf(func(_ context.Context, req planmodifier.BoolRequest, resp *boolplanmodifier.RequiresReplaceIfFuncResponse) {
+						resp.RequiresReplace = !req.StateValue.IsNull()
+					}, "Replaces the instance when changed, unless it was imported by id.", "Replaces the instance when changed, unless it was imported by id."),
+				},
+			},
+			"password_rotation_trigger": schema.StringAttribute{
+				Description: "Changing this value rotates the default neo4j user password in place, for example to a timestamp to rotate on a schedule. Setting it for the first time, as after an import, only records the value. The Aura API can not reset the password, it is changed with ALTER CURRENT USER through the query api of the database, signed in with the current password. It needs n4jusr, the current password in n4jpwd, a running instance and neo4j 5, so it can not recover a lost password nor rotate the password of an instance imported without its password or of a neo4j 4 instance.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("n4jpwd_wo")),
+				},
+			},
+			"n4jpwd_wo": schema.StringAttribute{
+				Description: "Write-only password to set for the default neo4j user, for example from the pgrneo4jaura_aurapassword ephemeral resource, so the password is never stored in state and n4jpwd is null. Needs Terraform 1.11 or later and neo4j 5. Applied on creation, once the database accepts connections, replacing the password Aura generated, which is not stored either, or when n4jpwd_wo_version changes while the current password is still in n4jpwd. When it can not be applied on creation the instance is kept tainted and replaced on the next apply. Once it is applied the provider no longer knows the password, change it in the database afterwards.",
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+			},
+			"n4jpwd_wo_version": schema.Int64Attribute{
+				Description: "Version of n4jpwd_wo, changing it applies n4jpwd_wo to an existing instance whose password is still in n4jpwd, moving it off the stored password.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("n4jpwd_wo")),
 				},
 			},
 			//computed, no default (retrieved after create)
@@ -205,14 +284,14 @@ func (r *neo4jAuraResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				},
 			},
 			"storage": schema.StringAttribute{
-				Description: "Neo4j Aura instance storage. The amount of storage depends on the amount of memory allocated for your instance.",
+				Description: "Neo4j Aura instance storage. The amount of storage depends on the amount of memory allocated for your instance and is known at plan time.",
 				Computed:    true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
 			"n4jpwd": schema.StringAttribute{
-				Description: "Default neo4j user password.",
+				Description: "Default neo4j user password, generated by Aura on creation and replaced when password_rotation_trigger changes. It is stored in plain text in state, set n4jpwd_wo to keep it out. N/A when n4jusr is false or after an import by id, null when the password is set write-only through n4jpwd_wo.",
 				Computed:    true,
 				Sensitive:   true,
 				PlanModifiers: []planmodifier.String{
@@ -227,6 +306,20 @@ func (r *neo4jAuraResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx, instanceTimeouts),
+		},
+	}
+}
+
+func (r *neo4jAuraResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.StringAttribute{
+				Description:       "Neo4j Aura instance id.",
+				RequiredForImport: true,
+			},
+		},
 	}
 }
 
@@ -235,7 +328,94 @@ func (r *neo4jAuraResource) Configure(_ context.Context, req resource.ConfigureR
 		return
 	}
 
-	r.access_token = req.ProviderData.(providerData).access_token
+	r.client = req.ProviderData.(providerData).client
+}
+
+// ModifyPlan checks the cloud_provider, region, type, version and memory of a
+// new or resized instance against the instance configurations of the tenant,
+// so an unavailable combination fails the plan instead of a slow apply. the
+// storage of the matching configuration is planned along with it. a referenced
+// customer managed key is checked the same way.
+func (r *neo4jAuraResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return // destroy, or the provider is not configured yet
+	}
+
+	var plan neo4jAuraResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var state neo4jAuraResourceModel
+	tupleChanged := true
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if passwordRotationRequested(state, plan) {
+			resp.Diagnostics.Append(validatePasswordChange(state, plan, "password_rotation_trigger")...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("n4jpwd"), types.StringUnknown())...)
+		}
+		if writeOnlyPasswordChanged(state, plan) {
+			resp.Diagnostics.Append(validatePasswordChange(state, plan, "n4jpwd_wo_version")...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("n4jpwd"), types.StringNull())...)
+		}
+		tupleChanged = state.TenantID != plan.TenantID || state.CloudProvider != plan.CloudProvider || state.Region != plan.Region ||
+			state.InstanceType != plan.InstanceType || state.Version != plan.Version || state.Memory != plan.Memory
+		if !tupleChanged && state.CMK == plan.CMK {
+			return
+		}
+	} else {
+		// a write-only password is set on creation and never stored
+		var passwordWO types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("n4jpwd_wo"), &passwordWO)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if !passwordWO.IsNull() {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("n4jpwd"), types.StringNull())...)
+		}
+	}
+	if !plan.CMK.IsUnknown() && plan.CMK.ValueString() != "" {
+		resp.Diagnostics.Append(r.validateCustomerManagedKey(ctx, plan)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	if !tupleChanged {
+		return
+	}
+	for _, value := range []types.String{plan.TenantID, plan.CloudProvider, plan.Region, plan.InstanceType, plan.Version, plan.Memory} {
+		if value.IsUnknown() || value.IsNull() {
+			// the storage kept from state no longer applies
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("storage"), types.StringUnknown())...)
+			return
+		}
+	}
+
+	tenantID := plan.TenantID.ValueString()
+	tenant, err := r.client.GetTenant(ctx, tenantID)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("tenant_id"),
+			"Error Reading Neo4j Aura tenant",
+			"Could not read instance configurations of Neo4j Aura tenant "+tenantID+". Received error: "+err.Error(),
+		)
+		return
+	}
+	storage, diags := validateInstanceConfiguration(tenant.InstanceConfigurations, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("storage"), storage)...)
 }
 
 func (r *neo4jAuraResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -246,6 +426,13 @@ func (r *neo4jAuraResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
+	ctx, cancel, diags := withOperationTimeout(ctx, plan.Timeouts, "create", instanceTimeouts["create"])
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	version := plan.Version.ValueString()
 	region := plan.Region.ValueString()
 	memory := plan.Memory.ValueString()
@@ -259,9 +446,33 @@ func (r *neo4jAuraResource) Create(ctx context.Context, req resource.CreateReque
 	vectorOptimized := plan.VectorOptimized.ValueBool()
 	gdsPluginIncluded := plan.GDSPlugin.ValueBool()
 	secondaryCount := plan.Secondaries.ValueInt64()
+	cdcEnrichmentMode := plan.CDC.ValueString()
 
-	tflog.Info(ctx, fmt.Sprintf("creating neo4j %s instance", instanceType))
-	instance, err := neo4jCreateInstance(ctx, r.access_token, version, region, memory, name, instanceType, tenantID, cloudProvider, cmk, vectorOptimized, gdsPluginIncluded)
+	createRequest := aura.CreateInstanceRequest{
+		Version:              version,
+		Region:               region,
+		Memory:               memory,
+		Name:                 name,
+		Type:                 instanceType,
+		TenantID:             tenantID,
+		CloudProvider:        cloudProvider,
+		CustomerManagedKeyID: cmk,
+		VectorOptimized:      vectorOptimized,
+		GraphAnalyticsPlugin: gdsPluginIncluded,
+	}
+	var instance *aura.Instance
+	var credentials *aura.Credentials
+	var err error
+	if sourceInstance := plan.SourceInstance.ValueString(); sourceInstance != "" {
+		tflog.Info(ctx, fmt.Sprintf("cloning neo4j instance %s into a new %s instance", sourceInstance, instanceType))
+		instance, credentials, err = r.client.CloneInstance(ctx, sourceInstance, aura.CloneInstanceRequest{
+			CreateInstanceRequest: createRequest,
+			SourceSnapshotID:      plan.SourceSnapshot.ValueString(),
+		})
+	} else {
+		tflog.Info(ctx, fmt.Sprintf("creating neo4j %s instance", instanceType))
+		instance, credentials, err = r.client.CreateInstance(ctx, createRequest)
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating Neo4j Aura instance",
@@ -271,11 +482,75 @@ func (r *neo4jAuraResource) Create(ctx context.Context, req resource.CreateReque
 	}
 
 	tflog.Info(ctx, "created neo4j instance")
-	tflog.Debug(ctx, "instance details: %v", instance)
-	instanceID := instance["data"].(map[string]interface{})["id"].(string)
-	storage := instance["data"].(map[string]interface{})["storage"].(string)
+	tflog.Debug(ctx, fmt.Sprintf("instance details: %+v", instance))
+	instanceID := instance.ID
+	var passwordWO types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("n4jpwd_wo"), &passwordWO)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = types.StringValue(instance.ID)
+	plan.ConnectionURL = types.StringValue(instance.ConnectionURL)
+	plan.MetricsURL = types.StringValue(instance.MetricsIntegrationURL)
+	if !passwordWO.IsNull() {
+		plan.NeoPwd = types.StringNull() // never stored, even when setting it fails
+	} else if n4jusr {
+		plan.NeoPwd = types.StringValue(credentials.Password)
+	} else {
+		plan.NeoPwd = types.StringValue("N/A")
+	}
+	if plan.Storage.IsUnknown() { // planned from the tenant configurations when known
+		plan.Storage = types.StringValue(instance.Storage)
+	}
+
+	// track the instance as created before the follow-up calls, when one of
+	// them fails the instance stays in state, tainted, instead of orphaned.
+	created := plan
+	created.Paused = types.BoolValue(false)
+	created.Secondaries = types.Int64Value(0)
+	created.CDC = types.StringValue("OFF")
+	if instance.CDCEnrichmentMode != "" {
+		created.CDC = types.StringValue(instance.CDCEnrichmentMode)
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, created)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, neo4jAuraResourceIdentityModel{ID: plan.ID})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the write-only password replaces the generated one while still running,
+	// once the new database accepts connections
+	if !passwordWO.IsNull() {
+		tflog.Info(ctx, fmt.Sprintf("setting write-only neo4j user password of instance %s", instanceID))
+		_, err := r.client.WaitForDatabase(ctx, instance.ConnectionURL)
+		if err == nil {
+			err = r.client.ChangePassword(ctx, instance.ConnectionURL, credentials.Username, credentials.Password, passwordWO.ValueString())
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Setting Neo4j Aura instance password",
+				"Could not set the write-only neo4j user password of Neo4j Aura instance "+instanceID+", it is replaced on the next apply. Received error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	// cdc can not be set on creation, apply it before a possible pause. a
+	// new instance starts with cdc off, even when the api leaves it out
+	if cdcEnrichmentMode != "OFF" && cdcEnrichmentMode != created.CDC.ValueString() {
+		updateCDCResponse, err := r.client.UpdateCDCEnrichmentMode(ctx, instanceID, cdcEnrichmentMode)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Updating Neo4j Aura instance cdc_enrichment_mode",
+				"Could not update Neo4j Aura instance cdc_enrichment_mode. Received error: "+err.Error(),
+			)
+			return
+		}
+		tflog.Debug(ctx, fmt.Sprintf("update cdc_enrichment_mode respose: %+v", updateCDCResponse))
+	}
 	if paused {
-		pauseResponse, err := neo4jPauseInstance(ctx, r.access_token, instanceID, true) //wait for pause to complete
+		pauseResponse, err := r.client.PauseInstance(ctx, instanceID, true) //wait for pause to complete
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error Pausing Neo4j Aura instance",
@@ -283,10 +558,10 @@ func (r *neo4jAuraResource) Create(ctx context.Context, req resource.CreateReque
 			)
 			return
 		}
-		tflog.Debug(ctx, fmt.Sprintf("pause respose: %v", pauseResponse))
+		tflog.Debug(ctx, fmt.Sprintf("pause respose: %+v", pauseResponse))
 	}
 	if secondaryCount > 0 {
-		updateSecondariesResponse, statusCode, err := neo4jUpdateSecondariesCount(ctx, r.access_token, instanceID, secondaryCount)
+		updateSecondariesResponse, err := r.client.UpdateSecondariesCount(ctx, instanceID, secondaryCount)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error Updating Secondaries Count",
@@ -294,20 +569,9 @@ func (r *neo4jAuraResource) Create(ctx context.Context, req resource.CreateReque
 			)
 			return
 		}
-		tflog.Debug(ctx, fmt.Sprintf("update secondaries respose(%d): %v", statusCode, updateSecondariesResponse))
+		tflog.Debug(ctx, fmt.Sprintf("update secondaries respose: %+v", updateSecondariesResponse))
 	}
 
-	plan.ID = types.StringValue(instance["data"].(map[string]interface{})["id"].(string))
-	plan.ConnectionURL = types.StringValue(instance["data"].(map[string]interface{})["connection_url"].(string))
-	plan.MetricsURL = types.StringValue(instance["data"].(map[string]interface{})["metrics_integration_url"].(string))
-	if n4jusr {
-		plan.NeoPwd = types.StringValue(instance["data"].(map[string]interface{})["n4jpwd"].(string))
-	} else {
-		plan.NeoPwd = types.StringValue("N/A")
-	}
-	plan.Storage = types.StringValue(storage)
-	plan.CMK = types.StringValue(cmk)
-
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -325,9 +589,17 @@ func (r *neo4jAuraResource) Read(ctx context.Context, req resource.ReadRequest,
 
 	id := state.ID.ValueString()
 
-	instance, statusCode, err := neo4jGetInstance(r.access_token, id)
+	instance, err := r.client.GetInstance(ctx, id)
 	tflog.Info(ctx, "reading neo4j instance")
-	tflog.Debug(ctx, fmt.Sprintf("instance details (http: %d): %v", statusCode, instance))
+	tflog.Debug(ctx, fmt.Sprintf("instance details: %+v", instance))
+	if aura.IsNotFound(err) {
+		resp.Diagnostics.AddWarning(
+			"Neo4j Aura instance not found",
+			"Neo4j Aura instance "+id+" no longer exists and has been removed from state. It was likely deleted outside of Terraform, apply will create it again.",
+		)
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Neo4j Aura instance",
@@ -336,17 +608,31 @@ func (r *neo4jAuraResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	state.ID = types.StringValue(instance["data"].(map[string]interface{})["id"].(string))
-	paused := instance["data"].(map[string]interface{})["status"].(string) == "paused"
-	state.Secondaries = types.Int64Value(instance["data"].(map[string]interface{})["secondaries_count"].(int64))
+	state.ID = types.StringValue(instance.ID)
+	state.Name = types.StringValue(instance.Name)
+	state.TenantID = types.StringValue(instance.TenantID)
+	state.CloudProvider = types.StringValue(instance.CloudProvider)
+	state.Region = types.StringValue(instance.Region)
+	state.InstanceType = types.StringValue(instance.Type)
+	// an instance without a cmk reads back as "", keep it null when unset
+	if instance.CustomerManagedKeyID != "" || state.CMK.ValueString() != "" {
+		state.CMK = types.StringValue(instance.CustomerManagedKeyID)
+	}
+	paused := instance.Status == "paused"
+	state.Secondaries = types.Int64Value(instance.SecondariesCount)
 	state.Paused = types.BoolValue(paused)
-	state.Memory = types.StringValue(instance["data"].(map[string]interface{})["memory"].(string))
-	state.VectorOptimized = types.BoolValue(instance["data"].(map[string]interface{})["vector_optimized"].(bool))
-	state.GDSPlugin = types.BoolValue(instance["data"].(map[string]interface{})["graph_analytics_plugin"].(bool))
-	state.MetricsURL = types.StringValue(instance["data"].(map[string]interface{})["metrics_integration_url"].(string))
+	if instance.Memory != "" { //missing in paused instances
+		state.Memory = types.StringValue(instance.Memory)
+	}
+	state.VectorOptimized = types.BoolValue(instance.VectorOptimized)
+	state.GDSPlugin = types.BoolValue(instance.GraphAnalyticsPlugin)
+	state.MetricsURL = types.StringValue(instance.MetricsIntegrationURL)
+	if instance.CDCEnrichmentMode != "" {
+		state.CDC = types.StringValue(instance.CDCEnrichmentMode)
+	}
 	if !paused {
-		state.ConnectionURL = types.StringValue(instance["data"].(map[string]interface{})["connection_url"].(string))
-		state.Storage = types.StringValue(instance["data"].(map[string]interface{})["storage"].(string))
+		state.ConnectionURL = types.StringValue(instance.ConnectionURL)
+		state.Storage = types.StringValue(instance.Storage)
 	}
 
 	diags = resp.State.Set(ctx, &state)
@@ -354,21 +640,26 @@ func (r *neo4jAuraResource) Read(ctx context.Context, req resource.ReadRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	// state written by earlier provider versions has no identity yet
+	diags = resp.Identity.Set(ctx, neo4jAuraResourceIdentityModel{ID: state.ID})
+	resp.Diagnostics.Append(diags...)
 }
 
-func doSerializedUpdates(ctx context.Context, token string, instanceID string, state neo4jAuraResourceModel, plan neo4jAuraResourceModel, resp *resource.UpdateResponse) (map[string]bool, error) {
+func doSerializedUpdates(ctx context.Context, client *aura.Client, instanceID string, state neo4jAuraResourceModel, plan neo4jAuraResourceModel, resp *resource.UpdateResponse) (map[string]bool, error) {
 	updates := map[string]bool{
 		"memory":                 false,
 		"vector_optimized":       false,
 		"graph_analytics_plugin": false,
 		"secondaries_count":      false,
+		"cdc_enrichment_mode":    false,
 	}
 
 	//decrease secondary instances to do modifications to less instances
 	if state.Secondaries.ValueInt64() > plan.Secondaries.ValueInt64() {
 		tflog.Info(ctx, "decreasing neo4j instance secondaries_count")
-		updateResponse, statusCode, err := neo4jUpdateSecondariesCount(ctx, token, instanceID, plan.Secondaries.ValueInt64())
-		tflog.Debug(ctx, fmt.Sprintf("Update secondaries_count response (%d): %v", statusCode, updateResponse))
+		updateResponse, err := client.UpdateSecondariesCount(ctx, instanceID, plan.Secondaries.ValueInt64())
+		tflog.Debug(ctx, fmt.Sprintf("Update secondaries_count response: %+v", updateResponse))
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error Updating Neo4j Aura instance secondaries_count",
@@ -379,10 +670,13 @@ func doSerializedUpdates(ctx context.Context, token string, instanceID string, s
 		updates["secondaries_count"] = true
 	}
 
-	if state.Memory != plan.Memory {
+	if state.Memory.IsNull() {
+		// imported while paused, the memory is corrected by Read after resume
+		tflog.Info(ctx, "neo4j instance memory unknown, adopting configured memory without resizing")
+	} else if state.Memory != plan.Memory {
 		tflog.Info(ctx, "updating neo4j instance memory")
-		updateResponse, statusCode, err := neo4jUpdateMemory(ctx, token, instanceID, plan.Memory.ValueString())
-		tflog.Debug(ctx, fmt.Sprintf("update response(%d): %v", statusCode, updateResponse))
+		updateResponse, err := client.UpdateMemory(ctx, instanceID, plan.Memory.ValueString())
+		tflog.Debug(ctx, fmt.Sprintf("update response: %+v", updateResponse))
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error Updating Neo4j Aura instance",
@@ -395,8 +689,8 @@ func doSerializedUpdates(ctx context.Context, token string, instanceID string, s
 
 	if state.VectorOptimized != plan.VectorOptimized {
 		tflog.Info(ctx, "updating neo4j instance vector optimzation")
-		updateResponse, statusCode, err := neo4jUpdateVectorOptimization(ctx, token, instanceID, plan.VectorOptimized.ValueBool())
-		tflog.Debug(ctx, fmt.Sprintf("Update vector optimzation response (%d): %v", statusCode, updateResponse))
+		updateResponse, err := client.UpdateVectorOptimization(ctx, instanceID, plan.VectorOptimized.ValueBool())
+		tflog.Debug(ctx, fmt.Sprintf("Update vector optimzation response: %+v", updateResponse))
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error Updating Neo4j Aura instance vector optimization",
@@ -409,8 +703,8 @@ func doSerializedUpdates(ctx context.Context, token string, instanceID string, s
 
 	if state.GDSPlugin != plan.GDSPlugin {
 		tflog.Info(ctx, "updating neo4j instance graph_analytics_plugin")
-		updateResponse, statusCode, err := neo4jUpdateIncludeGraphPlugin(ctx, token, instanceID, plan.GDSPlugin.ValueBool())
-		tflog.Debug(ctx, fmt.Sprintf("Update graph_analytics_plugin response (%d): %v", statusCode, updateResponse))
+		updateResponse, err := client.UpdateGraphAnalyticsPlugin(ctx, instanceID, plan.GDSPlugin.ValueBool())
+		tflog.Debug(ctx, fmt.Sprintf("Update graph_analytics_plugin response: %+v", updateResponse))
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error Updating Neo4j Aura instance graph_analytics_plugin",
@@ -421,11 +715,25 @@ func doSerializedUpdates(ctx context.Context, token string, instanceID string, s
 		updates["graph_analytics_plugin"] = true
 	}
 
+	if state.CDC != plan.CDC {
+		tflog.Info(ctx, "updating neo4j instance cdc_enrichment_mode")
+		updateResponse, err := client.UpdateCDCEnrichmentMode(ctx, instanceID, plan.CDC.ValueString())
+		tflog.Debug(ctx, fmt.Sprintf("Update cdc_enrichment_mode response: %+v", updateResponse))
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Updating Neo4j Aura instance cdc_enrichment_mode",
+				"Could not update Neo4j Aura instance cdc_enrichment_mode. Received error: "+err.Error(),
+			)
+			return nil, err
+		}
+		updates["cdc_enrichment_mode"] = true
+	}
+
 	//increase secondary instances after modifications
 	if state.Secondaries.ValueInt64() < plan.Secondaries.ValueInt64() {
 		tflog.Info(ctx, "increasing neo4j instance secondaries_count")
-		updateResponse, statusCode, err := neo4jUpdateSecondariesCount(ctx, token, instanceID, plan.Secondaries.ValueInt64())
-		tflog.Debug(ctx, fmt.Sprintf("Update secondaries_count response (%d): %v", statusCode, updateResponse))
+		updateResponse, err := client.UpdateSecondariesCount(ctx, instanceID, plan.Secondaries.ValueInt64())
+		tflog.Debug(ctx, fmt.Sprintf("Update secondaries_count response: %+v", updateResponse))
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error Updating Neo4j Aura instance secondaries_count",
@@ -454,6 +762,13 @@ func (r *neo4jAuraResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
+	ctx, cancel, diags := withOperationTimeout(ctx, plan.Timeouts, "update", instanceTimeouts["update"])
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	instanceID := state.ID.ValueString()
 	name := plan.Name.ValueString()
 	tflog.Info(ctx, fmt.Sprintf("updating neo4j instance %s with id %s", name, instanceID))
@@ -461,8 +776,7 @@ func (r *neo4jAuraResource) Update(ctx context.Context, req resource.UpdateReque
 	// renaming can be performed paused/unpaused
 	if state.Name != plan.Name {
 		tflog.Info(ctx, "renaming neo4j instance")
-		renameResponse, err := neo4jRenameInstance(r.access_token, instanceID, name)
-		tflog.Debug(ctx, fmt.Sprintf("Rename response: %v", renameResponse))
+		err := r.client.RenameInstance(ctx, instanceID, name)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error Renaming Neo4j Aura instance",
@@ -474,8 +788,8 @@ func (r *neo4jAuraResource) Update(ctx context.Context, req resource.UpdateReque
 
 	// adjust before pause
 	if plan.Paused.ValueBool() { //instance will be paused
-		// updateResponse, err := doCombinedUpdates(ctx, r.access_token, instanceID, state, plan, resp)
-		updates, err := doSerializedUpdates(ctx, r.access_token, instanceID, state, plan, resp)
+		// updateResponse, err := doCombinedUpdates(ctx, r.client, instanceID, state, plan, resp)
+		updates, err := doSerializedUpdates(ctx, r.client, instanceID, state, plan, resp)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error Updating Neo4j Aura instance",
@@ -483,7 +797,7 @@ func (r *neo4jAuraResource) Update(ctx context.Context, req resource.UpdateReque
 			)
 			return
 		}
-		if updates["memory"] || updates["vector_optimized"] || updates["graph_analytics_plugin"] || updates["secondaries_count"] { //if updateResponse != nil { when combinedupates
+		if updates["memory"] || updates["vector_optimized"] || updates["graph_analytics_plugin"] || updates["secondaries_count"] || updates["cdc_enrichment_mode"] { //if updateResponse != nil { when combinedupates
 			tflog.Info(ctx, fmt.Sprintf("update objects: %v", updates))
 		} else {
 			tflog.Info(ctx, "no updates to make before pause")
@@ -494,8 +808,8 @@ func (r *neo4jAuraResource) Update(ctx context.Context, req resource.UpdateReque
 	if state.Paused != plan.Paused {
 		if plan.Paused.ValueBool() {
 			tflog.Info(ctx, "pausing neo4j instance")
-			pauseResponse, err := neo4jPauseInstance(ctx, r.access_token, instanceID, true)
-			tflog.Debug(ctx, fmt.Sprintf("Pause response: %v", pauseResponse))
+			pauseResponse, err := r.client.PauseInstance(ctx, instanceID, true)
+			tflog.Debug(ctx, fmt.Sprintf("Pause response: %+v", pauseResponse))
 			if err != nil {
 				resp.Diagnostics.AddError(
 					"Error Pausing Neo4j Aura instance",
@@ -505,8 +819,8 @@ func (r *neo4jAuraResource) Update(ctx context.Context, req resource.UpdateReque
 			}
 		} else {
 			tflog.Info(ctx, "resuming neo4j instance")
-			resumeResponse, err := neo4jResumeInstance(ctx, r.access_token, instanceID, true)
-			tflog.Debug(ctx, fmt.Sprintf("Resume response: %v", resumeResponse))
+			resumeResponse, err := r.client.ResumeInstance(ctx, instanceID, true)
+			tflog.Debug(ctx, fmt.Sprintf("Resume response: %+v", resumeResponse))
 			if err != nil {
 				resp.Diagnostics.AddError(
 					"Error Resuming Neo4j Aura instance",
@@ -519,8 +833,8 @@ func (r *neo4jAuraResource) Update(ctx context.Context, req resource.UpdateReque
 
 	// adjust after unpause/resume
 	if !plan.Paused.ValueBool() { //instance was unpaused/resumed
-		// updateResponse, err := doCombinedUpdates(ctx, r.access_token, instanceID, state, plan, resp)
-		updates, err := doSerializedUpdates(ctx, r.access_token, instanceID, state, plan, resp)
+		// updateResponse, err := doCombinedUpdates(ctx, r.client, instanceID, state, plan, resp)
+		updates, err := doSerializedUpdates(ctx, r.client, instanceID, state, plan, resp)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error Updating Neo4j Aura instance",
@@ -528,25 +842,75 @@ func (r *neo4jAuraResource) Update(ctx context.Context, req resource.UpdateReque
 			)
 			return
 		}
-		if updates["memory"] || updates["vector_optimized"] || updates["graph_analytics_plugin"] || updates["secondaries_count"] { //if updateResponse != nil { when combinedupates
+		if updates["memory"] || updates["vector_optimized"] || updates["graph_analytics_plugin"] || updates["secondaries_count"] || updates["cdc_enrichment_mode"] { //if updateResponse != nil { when combinedupates
 			tflog.Info(ctx, fmt.Sprintf("update objects: %v", updates))
 		} else {
 			tflog.Info(ctx, "no updates to make before pause")
 		}
 	}
 
+	// the password is changed last, on the running instance
+	if passwordRotationRequested(state, plan) {
+		password, err := r.rotatePassword(ctx, instanceID, state)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Rotating Neo4j Aura instance password",
+				"Could not rotate the neo4j user password of Neo4j Aura instance. Received error: "+err.Error(),
+			)
+			return
+		}
+		state.NeoPwd = types.StringValue(password)
+	}
+	if writeOnlyPasswordChanged(state, plan) {
+		var passwordWO types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("n4jpwd_wo"), &passwordWO)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		tflog.Info(ctx, fmt.Sprintf("setting write-only neo4j user password of instance %s", instanceID))
+		if err := r.changePassword(ctx, instanceID, state, passwordWO.ValueString()); err != nil {
+			resp.Diagnostics.AddError(
+				"Error Setting Neo4j Aura instance password",
+				"Could not set the write-only neo4j user password of Neo4j Aura instance. Received error: "+err.Error(),
+			)
+			return
+		}
+		state.NeoPwd = types.StringNull()
+	}
+
 	state.Name = plan.Name
 	state.Paused = plan.Paused
+	state.PwdRotation = plan.PwdRotation
+	state.PwdWOVersion = plan.PwdWOVersion
+	state.NeoUser = plan.NeoUser
+	state.SourceInstance = plan.SourceInstance
+	state.SourceSnapshot = plan.SourceSnapshot
 	state.Memory = plan.Memory
+	if !plan.Storage.IsUnknown() {
+		state.Storage = plan.Storage
+	} else if instance, err := r.client.GetInstance(ctx, instanceID); err != nil {
+		// the updates are applied, keep them in state along with the error
+		resp.Diagnostics.AddError(
+			"Error Reading Neo4j Aura instance",
+			"Could not read the storage of Neo4j Aura instance "+instanceID+" after the update, it is refreshed on the next plan. Received error: "+err.Error(),
+		)
+	} else if instance.Storage != "" {
+		state.Storage = types.StringValue(instance.Storage)
+	}
 	state.VectorOptimized = plan.VectorOptimized
 	state.GDSPlugin = plan.GDSPlugin
 	state.Secondaries = plan.Secondaries
+	state.CDC = plan.CDC
+	state.Timeouts = plan.Timeouts
 
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	diags = resp.Identity.Set(ctx, neo4jAuraResourceIdentityModel{ID: state.ID})
+	resp.Diagnostics.Append(diags...)
 }
 
 func (r *neo4jAuraResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -557,9 +921,20 @@ func (r *neo4jAuraResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
+	ctx, cancel, diags := withOperationTimeout(ctx, state.Timeouts, "delete", instanceTimeouts["delete"])
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	id := state.ID.ValueString()
 	tflog.Info(ctx, fmt.Sprintf("deleting neo4j instance with id %s", id))
-	_, err := neo4jDeleteInstance(ctx, r.access_token, id)
+	err := r.client.DeleteInstance(ctx, id)
+	if aura.IsNotFound(err) {
+		tflog.Info(ctx, fmt.Sprintf("neo4j instance %s was already deleted", id))
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting Neo4j Aura instance",
@@ -571,28 +946,40 @@ func (r *neo4jAuraResource) Delete(ctx context.Context, req resource.DeleteReque
 
 // NOTE about "version"
 //   - /instances/{instanceId} response does not include instance version.
-//   - therefore, terraform import must additionaly be passed the version to sync state
-//   - the endpoint could also be updated to reflect the instances version so its 1 less input for the import
-//     ie. terraform import resource_type.resource_name <aura_instance_id> (* DOESNT WORK WITHOUT VERSION)
+//   - a running instance is asked through the http discovery endpoint of its
+//     connection url, otherwise the version is taken from the tenant when it
+//     only offers one version for the instance type and region.
+//   - the legacy format passes the version explicitly and is still accepted.
 //
+// terraform import pgrneo4jaura_aurainstance.myinstance <INSTANCE ID>
+// import { to = pgrneo4jaura_aurainstance.myinstance, identity = { id = <INSTANCE ID> } }
 // terraform import pgrneo4jaura_aurainstance.myinstance <INSTANCE ID>,<INSTANCE VERSION>,<INCL N4J USR>,(,<N4J USR PWD>)(,<INSTANCE MEMORY>)
 func (r *neo4jAuraResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	importParts := strings.Split(req.ID, ",")
-	if len(importParts) < 3 || len(importParts) > 5 {
+	importID := req.ID
+	if importID == "" { // import block by identity
+		var identity neo4jAuraResourceIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		importID = identity.ID.ValueString()
+	}
+
+	importParts := strings.Split(importID, ",")
+	if len(importParts) == 2 || len(importParts) > 5 {
 		resp.Diagnostics.AddError(
 			"Error Importing Neo4j Aura instance",
-			"Could not import Neo4j Aura instance.\nPlease ensure you run \"terraform import resource_type.resource_name <aura_instance_id>,<instance_version>,<include_neo4j_user>,(,<neo4j_user_pwd>)(,<instance_memory>)",
+			"Could not import Neo4j Aura instance.\nPlease ensure you run \"terraform import resource_type.resource_name <aura_instance_id>\" or \"terraform import resource_type.resource_name <aura_instance_id>,<instance_version>,<include_neo4j_user>,(,<neo4j_user_pwd>)(,<instance_memory>)\"",
 		)
 		return
 	}
+	legacy := len(importParts) >= 3
 
 	id := importParts[0]
-	version := importParts[1]
-	n4jUserIncl := importParts[2]
 
 	tflog.Info(ctx, "importing neo4j instance")
-	instance, statusCode, err := neo4jGetInstance(r.access_token, id)
-	tflog.Debug(ctx, fmt.Sprintf("instance details (http: %d): %v", statusCode, instance))
+	instance, err := r.client.GetInstance(ctx, id)
+	tflog.Debug(ctx, fmt.Sprintf("instance details: %+v", instance))
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -602,40 +989,112 @@ func (r *neo4jAuraResource) ImportState(ctx context.Context, req resource.Import
 		return
 	}
 
-	n4jusr := types.BoolValue(n4jUserIncl == "true")
+	// the tenant is only read when the instance itself can not tell
+	var tenant *aura.Tenant
+	getTenant := func() *aura.Tenant {
+		if tenant == nil {
+			tenant, err = r.client.GetTenant(ctx, instance.TenantID)
+			if err != nil {
+				tflog.Warn(ctx, fmt.Sprintf("unable to read tenant %s: %s", instance.TenantID, err))
+				tenant = &aura.Tenant{}
+			}
+		}
+		return tenant
+	}
+
+	// the default neo4j user password is only returned on creation, without
+	// the legacy format n4jusr is left unset so the configured value is adopted
+	n4jusr := types.BoolNull()
 	n4jpwd := "N/A"
-	if n4jUserIncl == "true" {
-		n4jpwd = importParts[3]
+	if legacy {
+		n4jUserIncl := importParts[2]
+		n4jusr = types.BoolValue(n4jUserIncl == "true")
+		if n4jUserIncl == "true" {
+			if len(importParts) < 4 {
+				resp.Diagnostics.AddError(
+					"Error Importing Neo4j Aura instance",
+					"Could not import Neo4j Aura instance.\nThe neo4j user password must follow <include_neo4j_user> when it is true.",
+				)
+				return
+			}
+			n4jpwd = importParts[3]
+		}
+	}
+
+	version := ""
+	if legacy {
+		version = importParts[1]
+	} else if instance.ConnectionURL != "" && instance.Status != "paused" {
+		version, err = r.client.DiscoverVersion(ctx, instance.ConnectionURL)
+		if err != nil {
+			tflog.Warn(ctx, fmt.Sprintf("unable to discover version of instance %s: %s", id, err))
+		}
+	}
+	if version == "" {
+		versions := map[string]bool{}
+		for _, config := range getTenant().InstanceConfigurations {
+			if config.CloudProvider == instance.CloudProvider && config.Region == instance.Region && config.Type == instance.Type {
+				versions[config.Version] = true
+			}
+		}
+		if len(versions) != 1 {
+			resp.Diagnostics.AddError(
+				"Error Importing Neo4j Aura instance",
+				"Could not determine the version of Neo4j Aura instance "+id+".\nPlease pass it explicitly with \"terraform import resource_type.resource_name <aura_instance_id>,<instance_version>,<include_neo4j_user>,(,<neo4j_user_pwd>)(,<instance_memory>)\"",
+			)
+			return
+		}
+		for v := range versions {
+			version = v
+		}
 	}
 
 	connection_url := "" //null connection_url in paused instances
-	paused := instance["data"].(map[string]interface{})["status"].(string) == "paused"
-	memory, storage := "paused", "paused"
+	paused := instance.Status == "paused"
+	memory, storage := types.StringNull(), types.StringNull()
 	if !paused {
-		connection_url = instance["data"].(map[string]interface{})["connection_url"].(string) //null in paused instances
-		memory = instance["data"].(map[string]interface{})["memory"].(string)                 //missing in paused instances
-		storage = instance["data"].(map[string]interface{})["storage"].(string)               //missing in paused instances
+		connection_url = instance.ConnectionURL //null in paused instances
+		memory = types.StringValue(instance.Memory)
+		storage = types.StringValue(instance.Storage)
+	} else if len(importParts) == 5 {
+		memory = types.StringValue(importParts[4])
+		storage = optionalString(instance.Storage)
 	} else {
-		if len(importParts) != 5 {
+		// paused instances do not report memory, each size of the tenant
+		// configurations comes with its own storage, which paused instances keep
+		memories := map[string]string{}
+		for _, config := range getTenant().InstanceConfigurations {
+			if config.CloudProvider == instance.CloudProvider && config.Region == instance.Region && config.Type == instance.Type && config.Version == version &&
+				(instance.Storage == "" || config.Storage == instance.Storage) {
+				memories[config.Memory] = config.Storage
+			}
+		}
+		if len(memories) != 1 {
 			resp.Diagnostics.AddError(
 				"Error Importing Neo4j Aura instance",
-				"Could not import Neo4j Aura instance.\nPlease ensure you run:\n  terraform import resource_type.resource_name <aura_instance_id>,<instance_version>,<include_neo4j_user>,(,<neo4j_user_pwd>)(,<instance_memory>)\nIf instance is paused for import you must also specify the memory in GB.",
+				"Could not determine the memory of paused Neo4j Aura instance "+id+".\nPlease pass it explicitly with \"terraform import resource_type.resource_name <aura_instance_id>,<instance_version>,<include_neo4j_user>,(,<neo4j_user_pwd>),<instance_memory>\"",
 			)
 			return
 		}
-		memory = importParts[4]
+		for m, s := range memories {
+			memory, storage = types.StringValue(m), types.StringValue(s)
+		}
 	}
-	cloud_provider := instance["data"].(map[string]interface{})["cloud_provider"].(string)
-	name := instance["data"].(map[string]interface{})["name"].(string)
-	region := instance["data"].(map[string]interface{})["region"].(string)
-	tenant_id := instance["data"].(map[string]interface{})["tenant_id"].(string)
-	instanceType := instance["data"].(map[string]interface{})["type"].(string)
+	cloud_provider := instance.CloudProvider
+	name := instance.Name
+	region := instance.Region
+	tenant_id := instance.TenantID
+	instanceType := instance.Type
 
-	secondaries := types.Int64Value(instance["data"].(map[string]interface{})["secondaries_count"].(int64))
-	vectorOptimized := types.BoolValue(instance["data"].(map[string]interface{})["vector_optimized"].(bool))
-	gdsPlugin := types.BoolValue(instance["data"].(map[string]interface{})["graph_analytics_plugin"].(bool))
-	metricsUrl := types.StringValue(instance["data"].(map[string]interface{})["metrics_integration_url"].(string))
-	cmk := types.StringValue(instance["data"].(map[string]interface{})["customer_managed_key_id"].(string))
+	secondaries := types.Int64Value(instance.SecondariesCount)
+	vectorOptimized := types.BoolValue(instance.VectorOptimized)
+	gdsPlugin := types.BoolValue(instance.GraphAnalyticsPlugin)
+	metricsUrl := types.StringValue(instance.MetricsIntegrationURL)
+	cmk := optionalString(instance.CustomerManagedKeyID)
+	cdcEnrichmentMode := instance.CDCEnrichmentMode
+	if cdcEnrichmentMode == "" {
+		cdcEnrichmentMode = "OFF"
+	}
 
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("connection_url"), connection_url)...)
@@ -645,6 +1104,7 @@ func (r *neo4jAuraResource) ImportState(ctx context.Context, req resource.Import
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("vector_optimized"), vectorOptimized)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("secondary_count"), secondaries)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("graph_analytics_plugin"), gdsPlugin)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cdc_enrichment_mode"), cdcEnrichmentMode)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("metrics_integration_url"), metricsUrl)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("n4jusr"), n4jusr)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("n4jpwd"), n4jpwd)...)
@@ -657,4 +1117,215 @@ func (r *neo4jAuraResource) ImportState(ctx context.Context, req resource.Import
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("type"), instanceType)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("version"), version)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("paused"), paused)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, neo4jAuraResourceIdentityModel{ID: types.StringValue(id)})...)
+}
+
+// requiresReplaceIfSet replaces the instance when a creation only attribute
+// changes from one value to another. it adopts a value when state has none,
+// as after an import, and forgets it when it is removed from the config.
+func requiresReplaceIfSet(_ context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	resp.RequiresReplace = !req.StateValue.IsNull() && !req.PlanValue.IsNull()
+}
+
+// passwordRotationRequested reports whether password_rotation_trigger changed.
+// a trigger set for the first time, or removed, is only recorded.
+func passwordRotationRequested(state neo4jAuraResourceModel, plan neo4jAuraResourceModel) bool {
+	return !state.PwdRotation.IsNull() && !plan.PwdRotation.IsNull() && !plan.PwdRotation.Equal(state.PwdRotation)
+}
+
+// writeOnlyPasswordChanged reports whether n4jpwd_wo_version changed, which
+// applies n4jpwd_wo again.
+func writeOnlyPasswordChanged(state neo4jAuraResourceModel, plan neo4jAuraResourceModel) bool {
+	return !plan.PwdWOVersion.IsNull() && !plan.PwdWOVersion.Equal(state.PwdWOVersion)
+}
+
+// validatePasswordChange checks the password can be changed by the attribute:
+// the current one signs in to the database, so it has to be in state and the
+// instance running a neo4j version with the query api.
+func validatePasswordChange(state neo4jAuraResourceModel, plan neo4jAuraResourceModel, attribute string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if !plan.NeoUser.ValueBool() || state.NeoPwd.IsNull() || state.NeoPwd.ValueString() == "N/A" {
+		diags.AddAttributeError(
+			path.Root(attribute),
+			"Invalid Neo4j Aura password change",
+			"The neo4j user password of Neo4j Aura instance "+state.ID.ValueString()+" is not in state, so "+attribute+" can not change it. "+
+				"The Aura API can not reset the password and the database only changes it signed in with the current one. "+
+				"It needs n4jusr = true and the password retrieved on creation or import, a write-only password can only be changed in the database.",
+		)
+	}
+	if plan.Paused.ValueBool() {
+		diags.AddAttributeError(
+			path.Root(attribute),
+			"Invalid Neo4j Aura password change",
+			"The neo4j user password of Neo4j Aura instance "+state.ID.ValueString()+" can only be changed while the instance is running, set paused = false.",
+		)
+	}
+	if !plan.Version.IsUnknown() && plan.Version.ValueString() != "5" {
+		diags.AddAttributeError(
+			path.Root(attribute),
+			"Invalid Neo4j Aura password change",
+			"The neo4j user password of Neo4j Aura instance "+state.ID.ValueString()+" can only be changed on neo4j 5, neo4j "+plan.Version.ValueString()+" has no query api to change it through.",
+		)
+	}
+	return diags
+}
+
+// randomPassword generates a neo4j user password, 43 url safe characters.
+func randomPassword() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// rotatePassword changes the neo4j user password to a new random one.
+func (r *neo4jAuraResource) rotatePassword(ctx context.Context, instanceID string, state neo4jAuraResourceModel) (string, error) {
+	password, err := randomPassword()
+	if err != nil {
+		return "", err
+	}
+	tflog.Info(ctx, fmt.Sprintf("rotating neo4j user password of instance %s", instanceID))
+	if err := r.changePassword(ctx, instanceID, state, password); err != nil {
+		return "", err
+	}
+	return password, nil
+}
+
+// changePassword changes the neo4j user password from the one in state through
+// the database itself, the Aura API can not reset it.
+func (r *neo4jAuraResource) changePassword(ctx context.Context, instanceID string, state neo4jAuraResourceModel, password string) error {
+	connectionURL := state.ConnectionURL.ValueString()
+	if connectionURL == "" { // unknown after an import of a paused instance
+		instance, err := r.client.GetInstance(ctx, instanceID)
+		if err != nil {
+			return err
+		}
+		connectionURL = instance.ConnectionURL
+	}
+	return r.client.ChangePassword(ctx, connectionURL, "neo4j", state.NeoPwd.ValueString(), password)
+}
+
+// validateCustomerManagedKey checks that the referenced cmk exists, is ready and
+// was created for the cloud provider, region and type of the instance.
+func (r *neo4jAuraResource) validateCustomerManagedKey(ctx context.Context, plan neo4jAuraResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	id := plan.CMK.ValueString()
+	cmk, err := r.client.GetCMK(ctx, id)
+	if aura.IsNotFound(err) {
+		diags.AddAttributeError(
+			path.Root("customer_managed_key_id"),
+			"Neo4j Aura CMK not found",
+			"Neo4j Aura CMK "+id+" does not exist.",
+		)
+		return diags
+	}
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("customer_managed_key_id"),
+			"Error Reading Neo4j Aura CMK",
+			"Could not read Neo4j Aura CMK "+id+". Received error: "+err.Error(),
+		)
+		return diags
+	}
+	if cmk.Status != "ready" {
+		diags.AddAttributeError(
+			path.Root("customer_managed_key_id"),
+			"Neo4j Aura CMK not ready",
+			fmt.Sprintf("Neo4j Aura CMK %s is %s, an instance can only use a ready cmk. Check the key grants in the cloud provider.", id, cmk.Status),
+		)
+		return diags
+	}
+	for _, check := range []struct {
+		name  string
+		value types.String
+		cmk   string
+	}{
+		{"tenant_id", plan.TenantID, cmk.TenantID},
+		{"cloud_provider", plan.CloudProvider, cmk.CloudProvider},
+		{"region", plan.Region, cmk.Region},
+		{"type", plan.InstanceType, cmk.InstanceType},
+	} {
+		if check.value.IsUnknown() || check.value.IsNull() || check.value.ValueString() == check.cmk {
+			continue
+		}
+		diags.AddAttributeError(
+			path.Root("customer_managed_key_id"),
+			"Invalid Neo4j Aura CMK",
+			fmt.Sprintf("Neo4j Aura CMK %s has %s %q, the instance has %q.", id, check.name, check.cmk, check.value.ValueString()),
+		)
+	}
+	return diags
+}
+
+// validateInstanceConfiguration returns the storage of the instance
+// configuration matching the plan, or an attribute error when the tenant does
+// not offer the planned combination.
+func validateInstanceConfiguration(configurations []aura.InstanceConfiguration, plan neo4jAuraResourceModel) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	cloudProvider := plan.CloudProvider.ValueString()
+	region := plan.Region.ValueString()
+	instanceType := plan.InstanceType.ValueString()
+	version := plan.Version.ValueString()
+	memory := plan.Memory.ValueString()
+
+	regions := map[string]bool{}
+	memories := map[string]bool{}
+	storage := ""
+	for _, config := range configurations {
+		if config.CloudProvider != cloudProvider || config.Type != instanceType || config.Version != version {
+			continue
+		}
+		regions[config.Region] = true
+		if config.Region == region {
+			memories[config.Memory] = true
+			if config.Memory == memory {
+				storage = config.Storage
+			}
+		}
+	}
+
+	if len(regions) == 0 {
+		diags.AddAttributeError(
+			path.Root("type"),
+			"Unavailable Neo4j Aura instance configuration",
+			fmt.Sprintf("The tenant does not offer %s instances of version %s in %s.", instanceType, version, cloudProvider),
+		)
+		return "", diags
+	}
+	if !regions[region] {
+		diags.AddAttributeError(
+			path.Root("region"),
+			"Unavailable Neo4j Aura instance region",
+			fmt.Sprintf("The tenant does not offer %s instances of version %s in %s region %s. Valid regions: %s.", instanceType, version, cloudProvider, region, strings.Join(sortedKeys(regions, strings.Compare), ", ")),
+		)
+		return "", diags
+	}
+	if !memories[memory] {
+		diags.AddAttributeError(
+			path.Root("memory"),
+			"Unavailable Neo4j Aura instance memory",
+			fmt.Sprintf("The tenant does not offer %s of memory for %s instances of version %s in %s region %s. Valid memory sizes: %s.", memory, instanceType, version, cloudProvider, region, strings.Join(sortedKeys(memories, compareMemory), ", ")),
+		)
+	}
+	return storage, diags
+}
+
+func sortedKeys(set map[string]bool, cmp func(a, b string) int) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, cmp)
+	return keys
+}
+
+// compareMemory orders memory sizes such as "2GB" and "16GB" numerically.
+func compareMemory(a, b string) int {
+	sizeA, errA := strconv.Atoi(strings.TrimSuffix(a, "GB"))
+	sizeB, errB := strconv.Atoi(strings.TrimSuffix(b, "GB"))
+	if errA != nil || errB != nil || sizeA == sizeB {
+		return strings.Compare(a, b)
+	}
+	return sizeA - sizeB
 }
@@ -1,19 +1,26 @@
 package pgrneo4jaura
 
 import (
+	"context"
 	"fmt"
+	"regexp"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-testing/compare"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
 
 func TestAccPGRNeo4jInstance(t *testing.T) {
 	t.Parallel()
 
-	tenantID := "00000000-0000-0000-0000-000000000000"
+	tenantID := testAccTenantID
 
 	// test for graph customer_managed_key_id, vector_optimized
 	resource.Test(t, resource.TestCase{
@@ -74,10 +81,25 @@ func TestAccPGRNeo4jInstance(t *testing.T) {
 						tfjsonpath.New("secondary_count"),
 						knownvalue.Int64Exact(0),
 					),
+					statecheck.ExpectKnownValue(
+						"pgrneo4jaura_aurainstance.instance",
+						tfjsonpath.New("timeouts").AtMapKey("update"),
+						knownvalue.StringExact("2h"),
+					),
 				},
 			},
 			{
 				Config: providerConfig + testAccCheckPGRNeo4jInstanceConfig(2, tenantID),
+				// storage follows the new memory size in the plan
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectKnownValue(
+							"pgrneo4jaura_aurainstance.instance",
+							tfjsonpath.New("storage"),
+							knownvalue.StringExact("16GB"),
+						),
+					},
+				},
 				ConfigStateChecks: []statecheck.StateCheck{
 					ExpectNotEmpty(
 						"pgrneo4jaura_aurainstance.instance",
@@ -245,6 +267,11 @@ func TestAccPGRNeo4jInstance(t *testing.T) {
 						tfjsonpath.New("secondary_count"),
 						knownvalue.Int64Exact(0),
 					),
+					statecheck.ExpectKnownValue(
+						"pgrneo4jaura_aurainstance.instance",
+						tfjsonpath.New("cdc_enrichment_mode"),
+						knownvalue.StringExact("DIFF"),
+					),
 				},
 			},
 			{
@@ -308,10 +335,594 @@ func TestAccPGRNeo4jInstance(t *testing.T) {
 	})
 }
 
+func TestAccPGRNeo4jInstanceInvalidConfiguration(t *testing.T) {
+	t.Parallel()
+
+	// unavailable combinations must fail the plan before anything is created
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      providerConfig + testAccCheckPGRNeo4jInvalidInstanceConfig(testAccTenantID, "us-east-1", "128GB"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Valid memory sizes: .*2GB`),
+			},
+			{
+				Config:      providerConfig + testAccCheckPGRNeo4jInvalidInstanceConfig(testAccTenantID, "moon-base-1", "4GB"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Valid regions: .*us-east-1`),
+			},
+		},
+	})
+}
+
+func TestAccPGRNeo4jInstanceDeletedOutsideTerraform(t *testing.T) {
+	if testAccAuraServer == nil {
+		t.Skip("deleting an instance out of band needs the fake Aura API")
+	}
+	t.Parallel()
+
+	config := providerConfig + fmt.Sprintf(`
+	resource "pgrneo4jaura_aurainstance" "drift" {
+		tenant_id = "%s"
+		name = "testproviderdrift"
+		type = "enterprise-db"
+		version = "5"
+		cloud_provider = "aws"
+		region = "us-east-1"
+		memory = "2GB"
+		n4jusr = false
+	}`, testAccTenantID)
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			{
+				PreConfig: func() {
+					if testAccAuraServer.RemoveInstances("testproviderdrift") != 1 {
+						t.Fatal("expected to remove instance testproviderdrift")
+					}
+				},
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("pgrneo4jaura_aurainstance.drift", plancheck.ResourceActionCreate),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAccPGRNeo4jInstanceRenamedOutsideTerraform(t *testing.T) {
+	t.Parallel()
+
+	config := providerConfig + fmt.Sprintf(`
+	resource "pgrneo4jaura_aurainstance" "renamed" {
+		tenant_id = "%s"
+		name = "testproviderrenamed"
+		type = "enterprise-db"
+		version = "5"
+		cloud_provider = "aws"
+		region = "us-east-1"
+		memory = "2GB"
+		n4jusr = false
+	}`, testAccTenantID)
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			{
+				// rename as if in the console, the plan must rename it back
+				PreConfig: func() {
+					ctx := context.Background()
+					client := testAccClient(t)
+					instances, err := client.GetInstances(ctx, testAccTenantID)
+					if err != nil {
+						t.Fatalf("unable to list instances: %v", err)
+					}
+					for _, instance := range instances {
+						if instance.Name == "testproviderrenamed" {
+							if err := client.RenameInstance(ctx, instance.ID, "testproviderrenamed-console"); err != nil {
+								t.Fatalf("unable to rename instance: %v", err)
+							}
+						}
+					}
+				},
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("pgrneo4jaura_aurainstance.renamed", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"pgrneo4jaura_aurainstance.renamed",
+						tfjsonpath.New("name"),
+						knownvalue.StringExact("testproviderrenamed"),
+					),
+				},
+			},
+		},
+	})
+}
+
+func TestAccPGRNeo4jInstanceImport(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + fmt.Sprintf(`
+				resource "pgrneo4jaura_aurainstance" "imported" {
+					tenant_id = "%s"
+					name = "testproviderimported"
+					type = "enterprise-db"
+					version = "5"
+					cloud_provider = "aws"
+					region = "us-east-1"
+					memory = "2GB"
+					n4jusr = false
+				}`, testAccTenantID),
+			},
+			{
+				// by id only, the version is discovered from the database
+				ResourceName:      "pgrneo4jaura_aurainstance.imported",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"n4jusr",
+					"n4jpwd",
+					"timeouts",
+				},
+			},
+		},
+	})
+}
+
+func TestAccPGRNeo4jInstanceImportPaused(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + fmt.Sprintf(`
+				resource "pgrneo4jaura_aurainstance" "paused" {
+					tenant_id = "%s"
+					name = "testproviderimportedpaused"
+					type = "enterprise-db"
+					version = "5"
+					cloud_provider = "aws"
+					region = "us-east-1"
+					memory = "4GB"
+					paused = true
+					n4jusr = false
+				}`, testAccTenantID),
+			},
+			{
+				// the memory is not reported while paused, it follows the storage
+				ResourceName:      "pgrneo4jaura_aurainstance.paused",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"connection_url",
+					"n4jusr",
+					"n4jpwd",
+					"timeouts",
+				},
+			},
+		},
+	})
+}
+
+func TestAccPGRNeo4jInstanceImportByIdentity(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + fmt.Sprintf(`
+				resource "pgrneo4jaura_aurainstance" "identity" {
+					tenant_id = "%s"
+					name = "testprovideridentity"
+					type = "enterprise-db"
+					version = "5"
+					cloud_provider = "aws"
+					region = "us-east-1"
+					memory = "2GB"
+					n4jusr = false
+				}`, testAccTenantID),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentityValueMatchesState("pgrneo4jaura_aurainstance.identity", tfjsonpath.New("id")),
+				},
+			},
+			{
+				// n4jusr can not be read back, the import adopts it in place
+				ResourceName:       "pgrneo4jaura_aurainstance.identity",
+				ImportState:        true,
+				ImportStateKind:    resource.ImportBlockWithResourceIdentity,
+				ExpectNonEmptyPlan: true,
+				ImportPlanChecks: resource.ImportPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("pgrneo4jaura_aurainstance.identity", plancheck.ResourceActionUpdate),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAccPGRNeo4jInstanceClone(t *testing.T) {
+	t.Parallel()
+
+	config := providerConfig + fmt.Sprintf(`
+	resource "pgrneo4jaura_aurainstance" "source" {
+		tenant_id = "%[1]s"
+		name = "testproviderclonesource"
+		type = "enterprise-db"
+		version = "5"
+		cloud_provider = "aws"
+		region = "us-east-1"
+		memory = "2GB"
+		n4jusr = false
+	}
+
+	resource "pgrneo4jaura_aurasnapshot" "source" {
+		instance_id = pgrneo4jaura_aurainstance.source.id
+	}
+
+	resource "pgrneo4jaura_aurainstance" "clone" {
+		tenant_id = "%[1]s"
+		name = "testproviderclone"
+		type = "enterprise-db"
+		version = "5"
+		cloud_provider = "aws"
+		region = "us-east-1"
+		memory = "2GB"
+		n4jusr = false
+		source_instance_id = pgrneo4jaura_aurainstance.source.id
+		source_snapshot_id = pgrneo4jaura_aurasnapshot.source.id
+	}`, testAccTenantID)
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				ConfigStateChecks: []statecheck.StateCheck{
+					ExpectNotEmpty(
+						"pgrneo4jaura_aurainstance.clone",
+						tfjsonpath.New("n4jpwd"),
+					),
+					statecheck.CompareValuePairs(
+						"pgrneo4jaura_aurainstance.clone",
+						tfjsonpath.New("source_snapshot_id"),
+						"pgrneo4jaura_aurasnapshot.source",
+						tfjsonpath.New("id"),
+						compare.ValuesSame(),
+					),
+				},
+				Check: func(s *terraform.State) error {
+					if testAccAuraServer == nil {
+						return nil
+					}
+					clone := s.RootModule().Resources["pgrneo4jaura_aurainstance.clone"].Primary
+					snapshot := s.RootModule().Resources["pgrneo4jaura_aurasnapshot.source"].Primary
+					if origin := testAccAuraServer.Origin(clone.ID); origin != "snapshot:"+snapshot.ID {
+						return fmt.Errorf("expected instance %s to be cloned from snapshot %s, got %q", clone.ID, snapshot.ID, origin)
+					}
+					return nil
+				},
+			},
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+			{
+				// dropping the sources from the config keeps the clone
+				Config: strings.NewReplacer(
+					"source_instance_id = pgrneo4jaura_aurainstance.source.id", "",
+					"source_snapshot_id = pgrneo4jaura_aurasnapshot.source.id", "",
+				).Replace(config),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("pgrneo4jaura_aurainstance.clone", plancheck.ResourceActionUpdate),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAccPGRNeo4jInstanceCloneInvalidConfiguration(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + fmt.Sprintf(`
+				resource "pgrneo4jaura_aurainstance" "clone" {
+					tenant_id = "%s"
+					name = "testproviderclone"
+					type = "enterprise-db"
+					version = "5"
+					cloud_provider = "aws"
+					region = "us-east-1"
+					memory = "2GB"
+					source_snapshot_id = "snapshot"
+				}`, testAccTenantID),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+		},
+	})
+}
+
+func TestAccPGRNeo4jInstancePasswordRotation(t *testing.T) {
+	t.Parallel()
+
+	config := func(paused bool, trigger string) string {
+		return providerConfig + fmt.Sprintf(`
+		resource "pgrneo4jaura_aurainstance" "rotated" {
+			tenant_id = "%s"
+			name = "testproviderrotation"
+			type = "enterprise-db"
+			version = "5"
+			cloud_provider = "aws"
+			region = "us-east-1"
+			memory = "2GB"
+			paused = %t
+			password_rotation_trigger = "%s"
+		}`, testAccTenantID, paused, trigger)
+	}
+	passwords := statecheck.CompareValue(compare.ValuesDiffer())
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(false, "1"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					passwords.AddStateValue("pgrneo4jaura_aurainstance.rotated", tfjsonpath.New("n4jpwd")),
+				},
+			},
+			{
+				// the instance is kept, only the password changes
+				Config: config(false, "2"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("pgrneo4jaura_aurainstance.rotated", plancheck.ResourceActionUpdate),
+						plancheck.ExpectUnknownValue("pgrneo4jaura_aurainstance.rotated", tfjsonpath.New("n4jpwd")),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					passwords.AddStateValue("pgrneo4jaura_aurainstance.rotated", tfjsonpath.New("n4jpwd")),
+				},
+				Check: func(s *terraform.State) error {
+					if testAccAuraServer == nil {
+						return nil
+					}
+					instance := s.RootModule().Resources["pgrneo4jaura_aurainstance.rotated"].Primary
+					if password := testAccAuraServer.Password(instance.ID); password != instance.Attributes["n4jpwd"] {
+						return fmt.Errorf("expected the rotated password of instance %s in state", instance.ID)
+					}
+					return nil
+				},
+			},
+			{
+				Config:      config(true, "3"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid Neo4j Aura password change`),
+			},
+		},
+	})
+}
+
+func TestAccPGRNeo4jInstancePasswordRotationAfterImport(t *testing.T) {
+	t.Parallel()
+
+	config := func(trigger string) string {
+		return providerConfig + fmt.Sprintf(`
+		resource "pgrneo4jaura_aurainstance" "imported" {
+			tenant_id = "%s"
+			name = "testproviderrotationimport"
+			type = "enterprise-db"
+			version = "5"
+			cloud_provider = "aws"
+			region = "us-east-1"
+			memory = "2GB"
+			password_rotation_trigger = "%s"
+		}`, testAccTenantID, trigger)
+	}
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("1"),
+			},
+			{
+				// imported by id, the password is not in state
+				ResourceName:       "pgrneo4jaura_aurainstance.imported",
+				ImportState:        true,
+				ImportStatePersist: true,
+			},
+			{
+				// records the trigger and n4jusr
+				Config: config("1"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"pgrneo4jaura_aurainstance.imported",
+						tfjsonpath.New("n4jpwd"),
+						knownvalue.StringExact("N/A"),
+					),
+				},
+			},
+			{
+				Config:      config("2"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid Neo4j Aura password change`),
+			},
+		},
+	})
+}
+
+func TestAccPGRNeo4jInstanceWriteOnlyPassword(t *testing.T) {
+	t.Parallel()
+
+	config := func(version int) string {
+		stored := ""
+		if version > 0 {
+			stored = fmt.Sprintf(`
+			n4jpwd_wo = "write-only-password-%d"
+			n4jpwd_wo_version = %d`, version, version)
+		}
+		return providerConfig + fmt.Sprintf(`
+		resource "pgrneo4jaura_aurainstance" "stored" {
+			tenant_id = "%[1]s"
+			name = "testproviderstoredpassword"
+			type = "enterprise-db"
+			version = "5"
+			cloud_provider = "aws"
+			region = "us-east-1"
+			memory = "2GB"%[2]s
+		}
+
+		resource "pgrneo4jaura_aurainstance" "writeonly" {
+			tenant_id = "%[1]s"
+			name = "testproviderwriteonlypassword"
+			type = "enterprise-db"
+			version = "5"
+			cloud_provider = "aws"
+			region = "us-east-1"
+			memory = "2GB"
+			n4jpwd_wo = "write-only-password"
+		}`, testAccTenantID, stored)
+	}
+	checkPassword := func(name string, password string) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			if testAccAuraServer == nil {
+				return nil
+			}
+			id := s.RootModule().Resources[name].Primary.ID
+			if testAccAuraServer.Password(id) != password {
+				return fmt.Errorf("expected the write-only password to be set on instance %s", id)
+			}
+			return nil
+		}
+	}
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config(0),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"pgrneo4jaura_aurainstance.writeonly",
+						tfjsonpath.New("n4jpwd"),
+						knownvalue.Null(),
+					),
+					statecheck.ExpectKnownValue(
+						"pgrneo4jaura_aurainstance.writeonly",
+						tfjsonpath.New("n4jpwd_wo"),
+						knownvalue.Null(),
+					),
+				},
+				Check: checkPassword("pgrneo4jaura_aurainstance.writeonly", "write-only-password"),
+			},
+			{
+				// moves the stored password out of state in place
+				Config: config(1),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("pgrneo4jaura_aurainstance.stored", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"pgrneo4jaura_aurainstance.stored",
+						tfjsonpath.New("n4jpwd"),
+						knownvalue.Null(),
+					),
+				},
+				Check: checkPassword("pgrneo4jaura_aurainstance.stored", "write-only-password-1"),
+			},
+			{
+				Config:   config(1),
+				PlanOnly: true,
+			},
+			{
+				// the current password is no longer known to change it from
+				Config:      config(2),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid Neo4j Aura password change`),
+			},
+		},
+	})
+}
+
+func TestAccPGRNeo4jInstanceWriteOnlyPasswordRefused(t *testing.T) {
+	t.Parallel()
+
+	config := providerConfig + fmt.Sprintf(`
+	resource "pgrneo4jaura_aurainstance" "refused" {
+		tenant_id = "%s"
+		name = "testproviderrefusedpassword"
+		type = "enterprise-db"
+		version = "5"
+		cloud_provider = "aws"
+		region = "us-east-1"
+		memory = "2GB"
+		n4jpwd_wo = "short"
+	}`, testAccTenantID)
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config:      config,
+				ExpectError: regexp.MustCompile(`Error Setting Neo4j Aura instance password`),
+			},
+			{
+				// the tainted instance is tracked without the generated password
+				RefreshState:       true,
+				ExpectNonEmptyPlan: true,
+				Check:              resource.TestCheckNoResourceAttr("pgrneo4jaura_aurainstance.refused", "n4jpwd"),
+			},
+		},
+	})
+}
+
+func testAccCheckPGRNeo4jInvalidInstanceConfig(tenant_id string, region string, memory string) string {
+	return fmt.Sprintf(`
+	resource "pgrneo4jaura_aurainstance" "invalid" {
+		tenant_id = "%s"
+		name = "testproviderinvalid"
+		type = "enterprise-db"
+		version = "5"
+		cloud_provider = "aws"
+		region = "%s"
+		memory = "%s"
+	}`, tenant_id, region, memory)
+}
+
 func testAccCheckPGRNeo4jInstanceConfig(testid int, tenant_id string) string {
 	itype, name, memory, paused, n4jusr := "enterprise-db", "", "", "", "true"
 	cmk, vectorOptimized, gdsPlugin := "", "true", "false"
-	secondaries := 0
+	secondaries, cdc := 0, "OFF"
 	//enterprise-db tier
 	if testid == 1 { // create
 		name = "testprovider"
@@ -327,11 +938,12 @@ func testAccCheckPGRNeo4jInstanceConfig(testid int, tenant_id string) string {
 		memory = "4GB"
 		paused = "false"
 		vectorOptimized = "true"
-	} else if testid == 4 { // no pause/unpause, update memory, vector optimized, name at same time
+	} else if testid == 4 { // no pause/unpause, update memory, vector optimized, name, cdc at same time
 		name = "testprovider2"
 		memory = "8GB"
 		paused = "false"
 		vectorOptimized = "false"
+		cdc = "DIFF"
 	} else if testid == 5 { // create instance and include neo4j password in state
 		name = "testproviderpwd"
 		memory = "4GB"
@@ -370,5 +982,10 @@ func testAccCheckPGRNeo4jInstanceConfig(testid int, tenant_id string) string {
 		vector_optimized = %s
 		graph_analytics_plugin = %s
 		secondary_count = %d
-	}`, tenant_id, name, itype, memory, paused, n4jusr, cmk, vectorOptimized, gdsPlugin, secondaries)
+		cdc_enrichment_mode = "%s"
+
+		timeouts {
+			update = "2h"
+		}
+	}`, tenant_id, name, itype, memory, paused, n4jusr, cmk, vectorOptimized, gdsPlugin, secondaries, cdc)
 }
@@ -0,0 +1,267 @@
+package pgrneo4jaura
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-pgrneo4jaura/aura"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                     = &neo4jAuraOverwriteResource{}
+	_ resource.ResourceWithConfigure        = &neo4jAuraOverwriteResource{}
+	_ resource.ResourceWithConfigValidators = &neo4jAuraOverwriteResource{}
+)
+
+// default operation timeouts, an overwrite is replaced rather than updated and
+// can not be undone.
+var overwriteTimeouts = map[string]time.Duration{
+	"create": 1 * time.Hour,
+}
+
+func NewAuraOverwriteResource() resource.Resource {
+	return &neo4jAuraOverwriteResource{}
+}
+
+type neo4jAuraOverwriteResource struct {
+	client *aura.Client
+}
+
+type neo4jAuraOverwriteResourceModel struct {
+	ID               types.String   `tfsdk:"id"`
+	InstanceID       types.String   `tfsdk:"instance_id"`
+	SourceInstanceID types.String   `tfsdk:"source_instance_id"`
+	SourceSnapshotID types.String   `tfsdk:"source_snapshot_id"`
+	Triggers         types.Map      `tfsdk:"triggers"`
+	Overwritten      types.String   `tfsdk:"overwritten"`
+	Status           types.String   `tfsdk:"status"`
+	ConnectionURL    types.String   `tfsdk:"connection_url"`
+	Storage          types.String   `tfsdk:"storage"`
+	Timeouts         timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *neo4jAuraOverwriteResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_auraoverwrite"
+}
+
+func (r *neo4jAuraOverwriteResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Overwrites the data of a running Neo4j Aura instance with the data of another instance, or of a snapshot, and waits for it to be running again. The overwrite runs on create and again whenever any argument or triggers changes, destroying the resource only removes it from state and does not undo the overwrite.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "identifier for resource, the id of the overwritten instance.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"instance_id": schema.StringAttribute{
+				Description: "Neo4j Aura instance to overwrite, it must be running. Its current data is replaced.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"source_instance_id": schema.StringAttribute{
+				Description: "Neo4j Aura instance to copy the data from, it must be running unless source_snapshot_id is set. At least one of source_instance_id and source_snapshot_id is required.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"source_snapshot_id": schema.StringAttribute{
+				Description: "Copy the data from this snapshot of source_instance_id rather than from its current data. Without source_instance_id it is a snapshot of instance_id itself.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary values that overwrite the instance again when they change, for example a timestamp to refresh on a schedule.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"overwritten": schema.StringAttribute{
+				Description: "Date/time the overwrite completed.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Description: "Neo4j Aura instance status, as last read.",
+				Computed:    true,
+			},
+			"connection_url": schema.StringAttribute{
+				Description: "Neo4j Aura instance connection url, as last read.",
+				Computed:    true,
+			},
+			"storage": schema.StringAttribute{
+				Description: "Neo4j Aura instance storage, as last read.",
+				Computed:    true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx, overwriteTimeouts),
+		},
+	}
+}
+
+func (r *neo4jAuraOverwriteResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.AtLeastOneOf(
+			path.MatchRoot("source_instance_id"),
+			path.MatchRoot("source_snapshot_id"),
+		),
+	}
+}
+
+func (r *neo4jAuraOverwriteResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(providerData).client
+}
+
+func (r *neo4jAuraOverwriteResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan neo4jAuraOverwriteResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withOperationTimeout(ctx, plan.Timeouts, "create", overwriteTimeouts["create"])
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	instanceID := plan.InstanceID.ValueString()
+	source := plan.SourceInstanceID.ValueString()
+	if !plan.SourceSnapshotID.IsNull() {
+		source = "snapshot " + plan.SourceSnapshotID.ValueString()
+	}
+	tflog.Info(ctx, fmt.Sprintf("overwriting neo4j instance %s from %s", instanceID, source))
+	instance, err := r.client.OverwriteInstance(ctx, instanceID, aura.OverwriteInstanceRequest{
+		SourceInstanceID: plan.SourceInstanceID.ValueString(),
+		SourceSnapshotID: plan.SourceSnapshotID.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Overwriting Neo4j Aura instance",
+			"Could not overwrite Neo4j Aura instance "+instanceID+" from "+source+". Received error: "+err.Error(),
+		)
+		return
+	}
+	tflog.Debug(ctx, fmt.Sprintf("instance details: %+v", instance))
+
+	plan.ID = types.StringValue(instanceID)
+	plan.Overwritten = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+	plan.refresh(instance)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *neo4jAuraOverwriteResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state neo4jAuraOverwriteResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// an overwrite can not be read back, only the instance it was applied to
+	instanceID := state.InstanceID.ValueString()
+	tflog.Info(ctx, fmt.Sprintf("reading overwritten neo4j instance %s", instanceID))
+	instance, err := r.client.GetInstance(ctx, instanceID)
+	tflog.Debug(ctx, fmt.Sprintf("instance details: %+v", instance))
+	if aura.IsNotFound(err) {
+		resp.Diagnostics.AddWarning(
+			"Neo4j Aura instance not found",
+			"Neo4j Aura instance "+instanceID+" no longer exists, its overwrite has been removed from state.",
+		)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Neo4j Aura instance",
+			"Could not read Neo4j Aura instance "+instanceID+". Received error: "+err.Error(),
+		)
+		return
+	}
+
+	state.refresh(instance)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *neo4jAuraOverwriteResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state neo4jAuraOverwriteResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan neo4jAuraOverwriteResourceModel
+	diags = req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "neo4j overwrite should require replace for any updates")
+	state.Timeouts = plan.Timeouts
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *neo4jAuraOverwriteResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state neo4jAuraOverwriteResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// an overwrite can not be undone, the instance keeps the copied data
+	tflog.Info(ctx, fmt.Sprintf("removing overwrite of neo4j instance %s from state, it keeps the copied data", state.InstanceID.ValueString()))
+}
+
+// refresh copies the computed attributes of the overwritten instance. a
+// paused instance has no connection url or storage.
+func (m *neo4jAuraOverwriteResourceModel) refresh(instance *aura.Instance) {
+	m.Status = types.StringValue(instance.Status)
+	m.ConnectionURL = optionalString(instance.ConnectionURL)
+	m.Storage = optionalString(instance.Storage)
+}
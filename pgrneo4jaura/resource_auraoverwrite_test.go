@@ -0,0 +1,125 @@
+package pgrneo4jaura
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccPGRNeo4jOverwrite(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccCheckPGRNeo4jOverwriteConfig(testAccTenantID, "1"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					ExpectNotEmpty(
+						"pgrneo4jaura_auraoverwrite.refresh",
+						tfjsonpath.New("overwritten"),
+					),
+					ExpectNotEmpty(
+						"pgrneo4jaura_auraoverwrite.refresh",
+						tfjsonpath.New("connection_url"),
+					),
+					statecheck.ExpectKnownValue(
+						"pgrneo4jaura_auraoverwrite.refresh",
+						tfjsonpath.New("status"),
+						knownvalue.StringExact("running"),
+					),
+					statecheck.ExpectKnownValue(
+						"pgrneo4jaura_auraoverwrite.refresh",
+						tfjsonpath.New("storage"),
+						knownvalue.StringExact("4GB"),
+					),
+				},
+				Check: testAccCheckPGRNeo4jOverwritten,
+			},
+			{
+				Config:   providerConfig + testAccCheckPGRNeo4jOverwriteConfig(testAccTenantID, "1"),
+				PlanOnly: true,
+			},
+			{
+				// a new trigger value runs the overwrite again
+				Config: providerConfig + testAccCheckPGRNeo4jOverwriteConfig(testAccTenantID, "2"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("pgrneo4jaura_auraoverwrite.refresh", plancheck.ResourceActionReplace),
+						plancheck.ExpectResourceAction("pgrneo4jaura_aurainstance.dev", plancheck.ResourceActionNoop),
+					},
+				},
+				Check: testAccCheckPGRNeo4jOverwritten,
+			},
+		},
+	})
+}
+
+func TestAccPGRNeo4jOverwriteWithoutSource(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+				resource "pgrneo4jaura_auraoverwrite" "nothing" {
+					instance_id = "00000000"
+				}`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+		},
+	})
+}
+
+func testAccCheckPGRNeo4jOverwritten(s *terraform.State) error {
+	if testAccAuraServer == nil {
+		return nil
+	}
+	overwrite := s.RootModule().Resources["pgrneo4jaura_auraoverwrite.refresh"].Primary.Attributes
+	if origin := testAccAuraServer.Origin(overwrite["instance_id"]); origin != "instance:"+overwrite["source_instance_id"] {
+		return fmt.Errorf("expected instance %s to hold the data of %s, got %q", overwrite["instance_id"], overwrite["source_instance_id"], origin)
+	}
+	return nil
+}
+
+func testAccCheckPGRNeo4jOverwriteConfig(tenant_id string, run string) string {
+	return fmt.Sprintf(`
+	resource "pgrneo4jaura_aurainstance" "prod" {
+		tenant_id = "%[1]s"
+		name = "testprovideroverwriteprod"
+		type = "enterprise-db"
+		version = "5"
+		cloud_provider = "aws"
+		region = "us-east-1"
+		memory = "2GB"
+		n4jusr = false
+	}
+
+	resource "pgrneo4jaura_aurainstance" "dev" {
+		tenant_id = "%[1]s"
+		name = "testprovideroverwritedev"
+		type = "enterprise-db"
+		version = "5"
+		cloud_provider = "aws"
+		region = "us-east-1"
+		memory = "2GB"
+		n4jusr = false
+	}
+
+	resource "pgrneo4jaura_auraoverwrite" "refresh" {
+		instance_id = pgrneo4jaura_aurainstance.dev.id
+		source_instance_id = pgrneo4jaura_aurainstance.prod.id
+		triggers = {
+			run = "%[2]s"
+		}
+	}`, tenant_id, run)
+}
@@ -0,0 +1,229 @@
+package pgrneo4jaura
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-pgrneo4jaura/aura"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource              = &neo4jAuraRestoreResource{}
+	_ resource.ResourceWithConfigure = &neo4jAuraRestoreResource{}
+)
+
+// default operation timeouts, a restore is replaced rather than updated and
+// can not be undone.
+var restoreTimeouts = map[string]time.Duration{
+	"create": 1 * time.Hour,
+}
+
+func NewAuraRestoreResource() resource.Resource {
+	return &neo4jAuraRestoreResource{}
+}
+
+type neo4jAuraRestoreResource struct {
+	client *aura.Client
+}
+
+type neo4jAuraRestoreResourceModel struct {
+	ID                types.String   `tfsdk:"id"`
+	InstanceID        types.String   `tfsdk:"instance_id"`
+	SnapshotID        types.String   `tfsdk:"snapshot_id"`
+	Triggers          types.Map      `tfsdk:"triggers"`
+	SnapshotTimestamp types.String   `tfsdk:"snapshot_timestamp"`
+	Restored          types.String   `tfsdk:"restored"`
+	Timeouts          timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *neo4jAuraRestoreResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_aurarestore"
+}
+
+func (r *neo4jAuraRestoreResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Restores a running Neo4j Aura instance in place from one of its snapshots and waits for it to be running again. The restore runs on create and again whenever instance_id, snapshot_id or triggers changes, destroying the resource only removes it from state and does not undo the restore.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "identifier for resource, <instance_id>/<snapshot_id>.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"instance_id": schema.StringAttribute{
+				Description: "Neo4j Aura instance to restore, it must be running. Its current data is overwritten.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"snapshot_id": schema.StringAttribute{
+				Description: "Neo4j Aura snapshot of instance_id to restore, it must be completed.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary values that restore the snapshot again when they change, for example to discard the changes made since the last restore.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"snapshot_timestamp": schema.StringAttribute{
+				Description: "Date/time of the restored snapshot.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"restored": schema.StringAttribute{
+				Description: "Date/time the restore completed.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx, restoreTimeouts),
+		},
+	}
+}
+
+func (r *neo4jAuraRestoreResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(providerData).client
+}
+
+func (r *neo4jAuraRestoreResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan neo4jAuraRestoreResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withOperationTimeout(ctx, plan.Timeouts, "create", restoreTimeouts["create"])
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	instanceID := plan.InstanceID.ValueString()
+	snapshotID := plan.SnapshotID.ValueString()
+	// look the snapshot up first, the restore endpoint does not say which
+	// point in time it applied
+	snapshot, err := r.client.GetSnapshot(ctx, instanceID, snapshotID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Restoring Neo4j Aura snapshot",
+			"Could not read snapshot "+snapshotID+" of Neo4j Aura instance "+instanceID+". Received error: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("restoring snapshot %s to neo4j instance %s", snapshotID, instanceID))
+	instance, err := r.client.RestoreSnapshot(ctx, instanceID, snapshotID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Restoring Neo4j Aura snapshot",
+			"Could not restore snapshot "+snapshotID+" to Neo4j Aura instance "+instanceID+". Received error: "+err.Error(),
+		)
+		return
+	}
+	tflog.Debug(ctx, fmt.Sprintf("instance details: %+v", instance))
+
+	plan.ID = types.StringValue(instanceID + "/" + snapshotID)
+	plan.SnapshotTimestamp = types.StringValue(snapshot.Timestamp)
+	plan.Restored = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *neo4jAuraRestoreResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state neo4jAuraRestoreResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// a restore can not be read back, only whether its instance still exists.
+	// the snapshot itself may expire without affecting the restored data.
+	instanceID := state.InstanceID.ValueString()
+	tflog.Info(ctx, fmt.Sprintf("reading neo4j instance %s of restore %s", instanceID, state.ID.ValueString()))
+	_, err := r.client.GetInstance(ctx, instanceID)
+	if aura.IsNotFound(err) {
+		resp.Diagnostics.AddWarning(
+			"Neo4j Aura instance not found",
+			"Neo4j Aura instance "+instanceID+" no longer exists, the restore of snapshot "+state.SnapshotID.ValueString()+" has been removed from state.",
+		)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Neo4j Aura instance",
+			"Could not read Neo4j Aura instance "+instanceID+". Received error: "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *neo4jAuraRestoreResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state neo4jAuraRestoreResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan neo4jAuraRestoreResourceModel
+	diags = req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "neo4j restore should require replace for any updates")
+	state.Timeouts = plan.Timeouts
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *neo4jAuraRestoreResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state neo4jAuraRestoreResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// a restore can not be undone, the instance keeps the restored data
+	tflog.Info(ctx, fmt.Sprintf("removing restore %s from state, neo4j instance %s keeps the restored data", state.ID.ValueString(), state.InstanceID.ValueString()))
+}
@@ -0,0 +1,95 @@
+package pgrneo4jaura
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/compare"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccPGRNeo4jRestore(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccCheckPGRNeo4jRestoreConfig(testAccTenantID, "1"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					ExpectNotEmpty(
+						"pgrneo4jaura_aurarestore.restore",
+						tfjsonpath.New("restored"),
+					),
+					statecheck.CompareValuePairs(
+						"pgrneo4jaura_aurarestore.restore",
+						tfjsonpath.New("snapshot_timestamp"),
+						"pgrneo4jaura_aurasnapshot.snapshot",
+						tfjsonpath.New("timestamp"),
+						compare.ValuesSame(),
+					),
+					statecheck.CompareValuePairs(
+						"pgrneo4jaura_aurarestore.restore",
+						tfjsonpath.New("instance_id"),
+						"pgrneo4jaura_aurainstance.restored",
+						tfjsonpath.New("id"),
+						compare.ValuesSame(),
+					),
+				},
+				Check: testAccCheckPGRNeo4jRestored,
+			},
+			{
+				// a new trigger value restores the same snapshot again
+				Config: providerConfig + testAccCheckPGRNeo4jRestoreConfig(testAccTenantID, "2"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("pgrneo4jaura_aurarestore.restore", plancheck.ResourceActionReplace),
+						plancheck.ExpectResourceAction("pgrneo4jaura_aurasnapshot.snapshot", plancheck.ResourceActionNoop),
+					},
+				},
+				Check: testAccCheckPGRNeo4jRestored,
+			},
+		},
+	})
+}
+
+func testAccCheckPGRNeo4jRestored(s *terraform.State) error {
+	if testAccAuraServer == nil {
+		return nil
+	}
+	restore := s.RootModule().Resources["pgrneo4jaura_aurarestore.restore"].Primary.Attributes
+	if origin := testAccAuraServer.Origin(restore["instance_id"]); origin != "snapshot:"+restore["snapshot_id"] {
+		return fmt.Errorf("expected instance %s to be restored from snapshot %s, got %q", restore["instance_id"], restore["snapshot_id"], origin)
+	}
+	return nil
+}
+
+func testAccCheckPGRNeo4jRestoreConfig(tenant_id string, run string) string {
+	return fmt.Sprintf(`
+	resource "pgrneo4jaura_aurainstance" "restored" {
+		tenant_id = "%[1]s"
+		name = "testproviderrestore"
+		type = "enterprise-db"
+		version = "5"
+		cloud_provider = "aws"
+		region = "us-east-1"
+		memory = "2GB"
+		n4jusr = false
+	}
+
+	resource "pgrneo4jaura_aurasnapshot" "snapshot" {
+		instance_id = pgrneo4jaura_aurainstance.restored.id
+	}
+
+	resource "pgrneo4jaura_aurarestore" "restore" {
+		instance_id = pgrneo4jaura_aurainstance.restored.id
+		snapshot_id = pgrneo4jaura_aurasnapshot.snapshot.id
+		triggers = {
+			run = "%[2]s"
+		}
+	}`, tenant_id, run)
+}
@@ -0,0 +1,272 @@
+package pgrneo4jaura
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"terraform-provider-pgrneo4jaura/aura"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = &neo4jAuraSnapshotResource{}
+	_ resource.ResourceWithConfigure   = &neo4jAuraSnapshotResource{}
+	_ resource.ResourceWithImportState = &neo4jAuraSnapshotResource{}
+)
+
+// default operation timeouts, a snapshot is replaced rather than updated and
+// the api can not delete one.
+var snapshotTimeouts = map[string]time.Duration{
+	"create": 30 * time.Minute,
+}
+
+func NewAuraSnapshotResource() resource.Resource {
+	return &neo4jAuraSnapshotResource{}
+}
+
+type neo4jAuraSnapshotResource struct {
+	client *aura.Client
+}
+
+type neo4jAuraSnapshotResourceModel struct {
+	ID         types.String   `tfsdk:"id"`
+	InstanceID types.String   `tfsdk:"instance_id"`
+	Profile    types.String   `tfsdk:"profile"`
+	Status     types.String   `tfsdk:"status"`
+	Timestamp  types.String   `tfsdk:"timestamp"`
+	Exportable types.Bool     `tfsdk:"exportable"`
+	Triggers   types.Map      `tfsdk:"triggers"`
+	Timeouts   timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *neo4jAuraSnapshotResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_aurasnapshot"
+}
+
+func (r *neo4jAuraSnapshotResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Takes an on demand snapshot of a running Neo4j Aura instance and waits for it to complete. Aura retains snapshots on its own schedule and has no api to delete them, destroying the resource only removes it from state.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Neo4j Aura snapshot id.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"instance_id": schema.StringAttribute{
+				Description: "Neo4j Aura instance to snapshot, it must be running.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary values that take a new snapshot when they change, for example a timestamp to snapshot on a schedule.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"profile": schema.StringAttribute{
+				Description: "Neo4j Aura snapshot profile, AdHoc for snapshots taken on demand.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Description: "Neo4j Aura snapshot status.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"timestamp": schema.StringAttribute{
+				Description: "Neo4j Aura snapshot date/time.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"exportable": schema.BoolAttribute{
+				Description: "Whether the snapshot can be exported.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx, snapshotTimeouts),
+		},
+	}
+}
+
+func (r *neo4jAuraSnapshotResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(providerData).client
+}
+
+func (r *neo4jAuraSnapshotResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan neo4jAuraSnapshotResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withOperationTimeout(ctx, plan.Timeouts, "create", snapshotTimeouts["create"])
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	instanceID := plan.InstanceID.ValueString()
+	tflog.Info(ctx, fmt.Sprintf("taking snapshot of neo4j instance %s", instanceID))
+	snapshot, err := r.client.CreateSnapshot(ctx, instanceID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Neo4j Aura snapshot",
+			"Could not take snapshot of Neo4j Aura instance "+instanceID+". Received error: "+err.Error(),
+		)
+		return
+	}
+	tflog.Debug(ctx, fmt.Sprintf("snapshot details: %+v", snapshot))
+
+	plan.ID = types.StringValue(snapshot.SnapshotID)
+	plan.Profile = types.StringValue(snapshot.Profile)
+	plan.Status = types.StringValue(snapshot.Status)
+	plan.Timestamp = types.StringValue(snapshot.Timestamp)
+	plan.Exportable = types.BoolValue(snapshot.Exportable)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *neo4jAuraSnapshotResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state neo4jAuraSnapshotResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := state.ID.ValueString()
+	instanceID := state.InstanceID.ValueString()
+	snapshot, err := r.client.GetSnapshot(ctx, instanceID, id)
+	tflog.Info(ctx, fmt.Sprintf("reading snapshot %s of neo4j instance %s", id, instanceID))
+	tflog.Debug(ctx, fmt.Sprintf("snapshot details: %+v", snapshot))
+	if aura.IsNotFound(err) {
+		resp.Diagnostics.AddWarning(
+			"Neo4j Aura snapshot not found",
+			"Snapshot "+id+" of Neo4j Aura instance "+instanceID+" no longer exists and has been removed from state. It was likely past its retention or the instance was deleted, apply will take a new snapshot.",
+		)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Neo4j Aura snapshot",
+			"Could not read snapshot "+id+" of Neo4j Aura instance "+instanceID+". Received error: "+err.Error(),
+		)
+		return
+	}
+
+	state.Profile = types.StringValue(snapshot.Profile)
+	state.Status = types.StringValue(snapshot.Status)
+	state.Timestamp = types.StringValue(snapshot.Timestamp)
+	state.Exportable = types.BoolValue(snapshot.Exportable)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *neo4jAuraSnapshotResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state neo4jAuraSnapshotResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan neo4jAuraSnapshotResourceModel
+	diags = req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "neo4j snapshot should require replace for any updates")
+	state.Timeouts = plan.Timeouts
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *neo4jAuraSnapshotResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state neo4jAuraSnapshotResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the api has no delete for snapshots, aura expires them on its own
+	tflog.Info(ctx, fmt.Sprintf("removing snapshot %s of neo4j instance %s from state, it is kept until aura expires it", state.ID.ValueString(), state.InstanceID.ValueString()))
+}
+
+// terraform import pgrneo4jaura_aurasnapshot.mysnapshot <INSTANCE ID>/<SNAPSHOT ID>
+func (r *neo4jAuraSnapshotResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	instanceID, id, ok := strings.Cut(req.ID, "/")
+	if !ok || instanceID == "" || id == "" {
+		resp.Diagnostics.AddError(
+			"Error Importing Neo4j Aura snapshot",
+			"Could not import Neo4j Aura snapshot.\nPlease ensure you run \"terraform import resource_type.resource_name <aura_instance_id>/<snapshot_id>\"",
+		)
+		return
+	}
+
+	tflog.Info(ctx, "importing neo4j snapshot")
+	snapshot, err := r.client.GetSnapshot(ctx, instanceID, id)
+	tflog.Debug(ctx, fmt.Sprintf("snapshot details: %+v", snapshot))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Importing Neo4j Aura snapshot",
+			"Could not import snapshot "+id+" of Neo4j Aura instance "+instanceID+". Received error: "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), snapshot.SnapshotID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("instance_id"), instanceID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("profile"), snapshot.Profile)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("status"), snapshot.Status)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("timestamp"), snapshot.Timestamp)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("exportable"), snapshot.Exportable)...)
+}
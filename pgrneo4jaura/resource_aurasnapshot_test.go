@@ -0,0 +1,122 @@
+package pgrneo4jaura
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/compare"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccPGRNeo4jSnapshot(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccCheckPGRNeo4jSnapshotConfig(testAccTenantID),
+				ConfigStateChecks: []statecheck.StateCheck{
+					ExpectNotEmpty(
+						"pgrneo4jaura_aurasnapshot.snapshot",
+						tfjsonpath.New("id"),
+					),
+					ExpectNotEmpty(
+						"pgrneo4jaura_aurasnapshot.snapshot",
+						tfjsonpath.New("timestamp"),
+					),
+					statecheck.ExpectKnownValue(
+						"pgrneo4jaura_aurasnapshot.snapshot",
+						tfjsonpath.New("status"),
+						knownvalue.StringExact("Completed"),
+					),
+					statecheck.ExpectKnownValue(
+						"pgrneo4jaura_aurasnapshot.snapshot",
+						tfjsonpath.New("profile"),
+						knownvalue.StringExact("AdHoc"),
+					),
+					statecheck.CompareValuePairs(
+						"pgrneo4jaura_aurasnapshot.snapshot",
+						tfjsonpath.New("instance_id"),
+						"pgrneo4jaura_aurainstance.snapshotted",
+						tfjsonpath.New("id"),
+						compare.ValuesSame(),
+					),
+				},
+			},
+			{
+				ResourceName: "pgrneo4jaura_aurasnapshot.snapshot",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					attributes := s.RootModule().Resources["pgrneo4jaura_aurasnapshot.snapshot"].Primary.Attributes
+					return attributes["instance_id"] + "/" + attributes["id"], nil
+				},
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccPGRNeo4jSnapshotTriggers(t *testing.T) {
+	t.Parallel()
+
+	config := func(run string) string {
+		return providerConfig + fmt.Sprintf(`
+	resource "pgrneo4jaura_aurainstance" "triggered" {
+		tenant_id = "%s"
+		name = "testprovidersnapshottriggers"
+		type = "enterprise-db"
+		version = "5"
+		cloud_provider = "aws"
+		region = "us-east-1"
+		memory = "2GB"
+		n4jusr = false
+	}
+
+	resource "pgrneo4jaura_aurasnapshot" "triggered" {
+		instance_id = pgrneo4jaura_aurainstance.triggered.id
+		triggers = {
+			run = "%s"
+		}
+	}`, testAccTenantID, run)
+	}
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("1"),
+			},
+			{
+				Config: config("2"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("pgrneo4jaura_aurasnapshot.triggered", plancheck.ResourceActionReplace),
+					},
+				},
+			},
+		},
+	})
+}
+
+func testAccCheckPGRNeo4jSnapshotConfig(tenant_id string) string {
+	return fmt.Sprintf(`
+	resource "pgrneo4jaura_aurainstance" "snapshotted" {
+		tenant_id = "%s"
+		name = "testprovidersnapshot"
+		type = "enterprise-db"
+		version = "5"
+		cloud_provider = "aws"
+		region = "us-east-1"
+		memory = "2GB"
+		n4jusr = false
+	}
+
+	resource "pgrneo4jaura_aurasnapshot" "snapshot" {
+		instance_id = pgrneo4jaura_aurainstance.snapshotted.id
+	}`, tenant_id)
+}
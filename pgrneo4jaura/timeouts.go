@@ -0,0 +1,69 @@
+package pgrneo4jaura
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+)
+
+// timeoutsBlock returns the standard `timeouts` block for a resource. defaults
+// holds the default per operation, an operation without a default is not
+// offered.
+func timeoutsBlock(ctx context.Context, defaults map[string]time.Duration) schema.Block {
+	description := func(operation string) string {
+		if def, ok := defaults[operation]; ok {
+			return fmt.Sprintf("Time to wait for the %s to complete, as a duration string such as \"45m\" or \"2h\". Defaults to %s.", operation, formatDuration(def))
+		}
+		return ""
+	}
+	_, create := defaults["create"]
+	_, update := defaults["update"]
+	_, remove := defaults["delete"]
+	block := timeouts.Block(ctx, timeouts.Opts{
+		Create:            create,
+		Update:            update,
+		Delete:            remove,
+		CreateDescription: description("create"),
+		UpdateDescription: description("update"),
+		DeleteDescription: description("delete"),
+	}).(schema.SingleNestedBlock)
+	block.Description = "Operation timeouts."
+	return block
+}
+
+// formatDuration drops the zero minutes and seconds time.Duration prints.
+func formatDuration(d time.Duration) string {
+	formatted := d.String()
+	if strings.HasSuffix(formatted, "m0s") {
+		formatted = strings.TrimSuffix(formatted, "0s")
+	}
+	if strings.HasSuffix(formatted, "h0m") {
+		formatted = strings.TrimSuffix(formatted, "0m")
+	}
+	return formatted
+}
+
+// withOperationTimeout bounds ctx by the configured timeout for operation,
+// falling back to def when the block or the attribute is not set.
+func withOperationTimeout(ctx context.Context, value timeouts.Value, operation string, def time.Duration) (context.Context, context.CancelFunc, diag.Diagnostics) {
+	var timeout time.Duration
+	var diags diag.Diagnostics
+	switch operation {
+	case "create":
+		timeout, diags = value.Create(ctx, def)
+	case "update":
+		timeout, diags = value.Update(ctx, def)
+	default:
+		timeout, diags = value.Delete(ctx, def)
+	}
+	if diags.HasError() {
+		return ctx, func() {}, diags
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	return ctx, cancel, diags
+}